@@ -21,11 +21,14 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	cloudsqlpgsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
@@ -50,6 +53,9 @@ func withDefaults(c server.ServerConfig) server.ServerConfig {
 	if c.TelemetryServiceName == "" {
 		c.TelemetryServiceName = "toolbox"
 	}
+	if c.McpSessionTTL == 0 {
+		c.McpSessionTTL = 30 * time.Minute
+	}
 	return c
 }
 
@@ -146,6 +152,20 @@ func TestServerConfigFlags(t *testing.T) {
 				LogLevel: "WARN",
 			}),
 		},
+		{
+			desc: "mcp page size",
+			args: []string{"--mcp-page-size", "50"},
+			want: withDefaults(server.ServerConfig{
+				McpPageSize: 50,
+			}),
+		},
+		{
+			desc: "mcp session ttl",
+			args: []string{"--mcp-session-ttl", "5m"},
+			want: withDefaults(server.ServerConfig{
+				McpSessionTTL: 5 * time.Minute,
+			}),
+		},
 		{
 			desc: "telemetry gcp",
 			args: []string{"--telemetry-gcp"},
@@ -408,6 +428,22 @@ func TestParseToolFile(t *testing.T) {
 			toolsets:
 				example_toolset:
 					- example_tool
+			resources:
+				example_resource:
+					uri: postgres://my-pg-instance/countries/us
+					description: some description
+					tool: example_tool
+					arguments:
+						country: US
+			prompts:
+				example_prompt:
+					description: some description
+					arguments:
+						- name: country
+							type: string
+							description: some description
+					messages:
+						- text: Summarize the data for {{.country}}.
 			`,
 			wantToolsFile: ToolsFile{
 				Sources: server.SourceConfigs{
@@ -442,6 +478,27 @@ func TestParseToolFile(t *testing.T) {
 						ToolNames: []string{"example_tool"},
 					},
 				},
+				Resources: server.ResourceConfigs{
+					"example_resource": resources.Config{
+						Name:        "example_resource",
+						URI:         "postgres://my-pg-instance/countries/us",
+						Description: "some description",
+						Tool:        "example_tool",
+						Arguments:   map[string]any{"country": "US"},
+					},
+				},
+				Prompts: server.PromptConfigs{
+					"example_prompt": prompts.Config{
+						Name:        "example_prompt",
+						Description: "some description",
+						Arguments: []tools.Parameter{
+							tools.NewStringParameter("country", "some description"),
+						},
+						Messages: []prompts.MessageConfig{
+							{Text: "Summarize the data for {{.country}}."},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -463,6 +520,12 @@ func TestParseToolFile(t *testing.T) {
 			if diff := cmp.Diff(tc.wantToolsFile.Toolsets, toolsFile.Toolsets); diff != "" {
 				t.Fatalf("incorrect tools parse: diff %v", diff)
 			}
+			if diff := cmp.Diff(tc.wantToolsFile.Resources, toolsFile.Resources); diff != "" {
+				t.Fatalf("incorrect resources parse: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.wantToolsFile.Prompts, toolsFile.Prompts); diff != "" {
+				t.Fatalf("incorrect prompts parse: diff %v", diff)
+			}
 		})
 	}
 
@@ -777,6 +840,99 @@ func TestParseToolFileWithAuth(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "basic example with policies",
+			in: `
+			sources:
+				my-pg-instance:
+					kind: cloud-sql-postgres
+					project: my-project
+					region: my-region
+					instance: my-instance
+					database: my_db
+					user: my_user
+					password: my_pass
+			authServices:
+				my-google-service:
+					kind: google
+					clientId: my-client-id
+
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					authRequired:
+						- my-google-service
+					policy:
+						anyOf:
+							- claim: groups
+								contains: analytics
+							- claim: email
+								endsWith: "@corp.com"
+
+			toolsets:
+				example_toolset:
+					tools:
+						- example_tool
+					policy:
+						allOf:
+							- authService: my-google-service
+								claim: hd
+								equals: corp.com
+			`,
+			wantToolsFile: ToolsFile{
+				Sources: server.SourceConfigs{
+					"my-pg-instance": cloudsqlpgsrc.Config{
+						Name:     "my-pg-instance",
+						Kind:     cloudsqlpgsrc.SourceKind,
+						Project:  "my-project",
+						Region:   "my-region",
+						Instance: "my-instance",
+						IPType:   "public",
+						Database: "my_db",
+						User:     "my_user",
+						Password: "my_pass",
+					},
+				},
+				AuthServices: server.AuthServiceConfigs{
+					"my-google-service": google.Config{
+						Name:     "my-google-service",
+						Kind:     google.AuthServiceKind,
+						ClientID: "my-client-id",
+					},
+				},
+				Tools: server.ToolConfigs{
+					"example_tool": postgressql.Config{
+						Name:         "example_tool",
+						Kind:         "postgres-sql",
+						Source:       "my-pg-instance",
+						Description:  "some description",
+						Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+						AuthRequired: []string{"my-google-service"},
+						Policy: &tools.Policy{
+							AnyOf: []tools.PolicyRule{
+								{Claim: "groups", Contains: "analytics"},
+								{Claim: "email", EndsWith: "@corp.com"},
+							},
+						},
+					},
+				},
+				Toolsets: server.ToolsetConfigs{
+					"example_toolset": tools.ToolsetConfig{
+						Name:      "example_toolset",
+						ToolNames: []string{"example_tool"},
+						Policy: &tools.Policy{
+							AllOf: []tools.PolicyRule{
+								{AuthService: "my-google-service", Claim: "hd", Equals: "corp.com"},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
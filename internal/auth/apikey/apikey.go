@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+)
+
+const AuthServiceKind string = "api-key"
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Key is an API key, and the claims returned for requests that use it.
+type Key struct {
+	Name   string         `yaml:"name" validate:"required"`
+	Key    string         `yaml:"key" validate:"required"`
+	Claims map[string]any `yaml:"claims"`
+}
+
+// Auth service configuration
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Keys     []Key  `yaml:"keys"`
+	KeysFile string `yaml:"keysFile"`
+}
+
+// Returns the auth service kind
+func (cfg Config) AuthServiceConfigKind() string {
+	return AuthServiceKind
+}
+
+// Initialize an API key auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	keys := cfg.Keys
+	if cfg.KeysFile != "" {
+		fileKeys, err := readKeysFile(cfg.KeysFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, fileKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key must be set in keys or keysFile")
+	}
+
+	// keys are looked up by their hash, so that a lookup doesn't leak the
+	// keys through timing
+	hashes := make(map[[sha256.Size]byte]Key, len(keys))
+	for _, k := range keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("key %q must not be empty", k.Name)
+		}
+		h := sha256.Sum256([]byte(k.Key))
+		if _, ok := hashes[h]; ok {
+			return nil, fmt.Errorf("key %q is a duplicate of another key", k.Name)
+		}
+		hashes[h] = k
+	}
+
+	a := &AuthService{
+		Name: cfg.Name,
+		Kind: AuthServiceKind,
+		keys: hashes,
+	}
+	return a, nil
+}
+
+// readKeysFile reads a list of keys from a YAML or JSON file.
+func readKeysFile(path string) ([]Key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read keys file: %w", err)
+	}
+	var keys []Key
+	if err := yaml.UnmarshalWithOptions(b, &keys, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("unable to parse keys file: %w", err)
+	}
+	return keys, nil
+}
+
+var _ auth.AuthService = AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+
+	keys map[[sha256.Size]byte]Key
+}
+
+// Returns the auth service kind
+func (a AuthService) AuthServiceKind() string {
+	return AuthServiceKind
+}
+
+// Returns the name of the auth service
+func (a AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the API key and returns its claims. The name of the key is
+// returned as the `sub` claim, unless it is set in the claims of the key.
+func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	key := h.Get(a.Name + "_token")
+	if key == "" {
+		return nil, nil
+	}
+	k, ok := a.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, fmt.Errorf("API key verification failure: unknown key")
+	}
+	claims := map[string]any{"sub": k.Name}
+	maps.Copy(claims, k.Claims)
+	return claims, nil
+}
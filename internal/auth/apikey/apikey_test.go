@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlApiKey(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	authServices:
+		my-api-key:
+			kind: api-key
+			keys:
+				- name: reporting-job
+					key: secret-key
+					claims:
+						tenant: acme
+			keysFile: /etc/toolbox/keys.yaml
+	`
+	want := server.AuthServiceConfigs{
+		"my-api-key": apikey.Config{
+			Name: "my-api-key",
+			Kind: "api-key",
+			Keys: []apikey.Key{
+				{Name: "reporting-job", Key: "secret-key", Claims: map[string]any{"tenant": "acme"}},
+			},
+			KeysFile: "/etc/toolbox/keys.yaml",
+		},
+	}
+	got := struct {
+		AuthServices server.AuthServiceConfigs `yaml:"authServices"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.AuthServices); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.yaml")
+	err := os.WriteFile(keysFile, []byte("- name: file-job\n  key: file-key\n"), 0o600)
+	if err != nil {
+		t.Fatalf("unable to write keys file: %s", err)
+	}
+	cfg := apikey.Config{
+		Name: "my-api-key",
+		Kind: apikey.AuthServiceKind,
+		Keys: []apikey.Key{
+			{Name: "reporting-job", Key: "secret-key", Claims: map[string]any{"tenant": "acme"}},
+		},
+		KeysFile: keysFile,
+	}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		name    string
+		key     string
+		want    map[string]any
+		wantErr bool
+	}{
+		{name: "inline key", key: "secret-key", want: map[string]any{"sub": "reporting-job", "tenant": "acme"}},
+		{name: "key from file", key: "file-key", want: map[string]any{"sub": "file-job"}},
+		{name: "no key", key: "", want: nil},
+		{name: "unknown key", key: "other-key", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			h := http.Header{}
+			if tc.key != "" {
+				h.Set("my-api-key_token", tc.key)
+			}
+			got, err := a.GetClaimsFromHeader(context.Background(), h)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got claims %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect claims: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		name string
+		cfg  apikey.Config
+	}{
+		{
+			name: "no keys",
+			cfg:  apikey.Config{Name: "my-api-key", Kind: apikey.AuthServiceKind},
+		},
+		{
+			name: "duplicate keys",
+			cfg: apikey.Config{
+				Name: "my-api-key",
+				Kind: apikey.AuthServiceKind,
+				Keys: []apikey.Key{{Name: "a", Key: "same"}, {Name: "b", Key: "same"}},
+			},
+		},
+		{
+			name: "missing keys file",
+			cfg:  apikey.Config{Name: "my-api-key", Kind: apikey.AuthServiceKind, KeysFile: "/does/not/exist.yaml"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.cfg.Initialize(); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
 )
 
@@ -31,3 +32,46 @@ type AuthService interface {
 	GetName() string
 	GetClaimsFromHeader(context.Context, http.Header) (map[string]any, error)
 }
+
+// ErrInsufficientScope is returned by auth services when a token is valid, but
+// doesn't grant the scopes that are required.
+var ErrInsufficientScope = errors.New("insufficient scope")
+
+// ProtectedResource is an auth service that protects the MCP endpoint as an
+// OAuth 2.0 resource server. Clients must send a bearer token verified by it
+// with every request.
+type ProtectedResource interface {
+	AuthService
+	// ResourceMetadata returns the metadata that clients use to find the
+	// authorization servers of the resource.
+	ResourceMetadata() ProtectedResourceMetadata
+	// ResourceMetadataPath returns the path where the metadata is served.
+	ResourceMetadataPath() string
+}
+
+// ProtectedResourceMetadata is the OAuth 2.0 Protected Resource Metadata
+// (RFC 9728) of a protected resource.
+type ProtectedResourceMetadata struct {
+	Resource               string   `json:"resource"`
+	AuthorizationServers   []string `json:"authorization_servers"`
+	ScopesSupported        []string `json:"scopes_supported,omitempty"`
+	BearerMethodsSupported []string `json:"bearer_methods_supported"`
+	ResourceName           string   `json:"resource_name,omitempty"`
+}
+
+// RequestAuthService is an auth service that verifies the whole request, e.g.
+// a signature of its body, instead of only its headers. Implementations must
+// leave the body of the request readable.
+type RequestAuthService interface {
+	AuthService
+	GetClaimsFromRequest(context.Context, *http.Request) (map[string]any, error)
+}
+
+// TokenAuthService is an auth service that verifies bearer tokens, which can
+// be forwarded to upstream APIs on behalf of the caller.
+type TokenAuthService interface {
+	AuthService
+	// GetTokenFromHeader returns the raw token sent for the auth service, or
+	// an empty string if there is none.
+	GetTokenFromHeader(http.Header) string
+}
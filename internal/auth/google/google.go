@@ -50,7 +50,7 @@ func (cfg Config) Initialize() (auth.AuthService, error) {
 	return a, nil
 }
 
-var _ auth.AuthService = AuthService{}
+var _ auth.TokenAuthService = AuthService{}
 
 // struct used to store auth service info
 type AuthService struct {
@@ -69,9 +69,14 @@ func (a AuthService) GetName() string {
 	return a.Name
 }
 
+// Returns the Google ID token sent in the `<name>_token` header
+func (a AuthService) GetTokenFromHeader(h http.Header) string {
+	return h.Get(a.Name + "_token")
+}
+
 // Verifies Google ID token and return claims
 func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
-	if token := h.Get(a.Name + "_token"); token != "" {
+	if token := a.GetTokenFromHeader(h); token != "" {
 		payload, err := idtoken.Validate(ctx, token, a.ClientID)
 		if err != nil {
 			return nil, fmt.Errorf("Google ID token verification failure: %w", err) //nolint:staticcheck
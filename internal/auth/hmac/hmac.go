@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hmac
+
+import (
+	"bytes"
+	"context"
+	stdhmac "crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+)
+
+const AuthServiceKind string = "hmac"
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Key is a shared secret used to sign requests, and the claims returned for
+// requests signed with it.
+type Key struct {
+	Id     string         `yaml:"id" validate:"required"`
+	Secret string         `yaml:"secret" validate:"required"`
+	Claims map[string]any `yaml:"claims"`
+}
+
+// Auth service configuration
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Keys     []Key  `yaml:"keys"`
+	KeysFile string `yaml:"keysFile"`
+	MaxSkew  string `yaml:"maxSkew"`
+}
+
+// Returns the auth service kind
+func (cfg Config) AuthServiceConfigKind() string {
+	return AuthServiceKind
+}
+
+// Initialize an HMAC auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	maxSkew := 5 * time.Minute
+	if cfg.MaxSkew != "" {
+		var err error
+		maxSkew, err = time.ParseDuration(cfg.MaxSkew)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse maxSkew string as time.Duration: %s", err)
+		}
+	}
+
+	keys := cfg.Keys
+	if cfg.KeysFile != "" {
+		b, err := os.ReadFile(cfg.KeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read keys file: %w", err)
+		}
+		var fileKeys []Key
+		if err := yaml.UnmarshalWithOptions(b, &fileKeys, yaml.Strict()); err != nil {
+			return nil, fmt.Errorf("unable to parse keys file: %w", err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key must be set in keys or keysFile")
+	}
+	keysById := make(map[string]Key, len(keys))
+	for _, k := range keys {
+		if k.Secret == "" {
+			return nil, fmt.Errorf("secret of key %q must not be empty", k.Id)
+		}
+		if _, ok := keysById[k.Id]; ok {
+			return nil, fmt.Errorf("key id %q is not unique", k.Id)
+		}
+		keysById[k.Id] = k
+	}
+
+	a := &AuthService{
+		Name:    cfg.Name,
+		Kind:    AuthServiceKind,
+		MaxSkew: maxSkew,
+		keys:    keysById,
+		nonces:  &nonceCache{seen: make(map[string]time.Time)},
+	}
+	return a, nil
+}
+
+var _ auth.RequestAuthService = AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Name    string        `yaml:"name"`
+	Kind    string        `yaml:"kind"`
+	MaxSkew time.Duration `yaml:"maxSkew"`
+
+	keys   map[string]Key
+	nonces *nonceCache
+}
+
+// Returns the auth service kind
+func (a AuthService) AuthServiceKind() string {
+	return AuthServiceKind
+}
+
+// Returns the name of the auth service
+func (a AuthService) GetName() string {
+	return a.Name
+}
+
+// GetClaimsFromHeader always fails for signed requests. Signatures cover the
+// method, path and body of the request, so they can't be verified with the
+// headers alone: HMAC auth services only authenticate callers through
+// GetClaimsFromRequest, i.e. on the tool invocation and MCP HTTP endpoints.
+func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	if h.Get(a.Name+"_signature") == "" {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("HMAC signature verification failure: auth service %q verifies whole requests and can't be used where only the headers are available", a.Name)
+}
+
+// Verifies the signature of the request and returns the claims of its key.
+// The id of the key is returned as the `sub` claim, unless it is set in the
+// claims of the key.
+func (a AuthService) GetClaimsFromRequest(ctx context.Context, r *http.Request) (map[string]any, error) {
+	signature := r.Header.Get(a.Name + "_signature")
+	if signature == "" {
+		return nil, nil
+	}
+	keyId := r.Header.Get(a.Name + "_key_id")
+	timestamp := r.Header.Get(a.Name + "_timestamp")
+	nonce := r.Header.Get(a.Name + "_nonce")
+	if keyId == "" || timestamp == "" || nonce == "" {
+		return nil, fmt.Errorf("HMAC signature verification failure: missing key id, timestamp or nonce")
+	}
+	k, ok := a.keys[keyId]
+	if !ok {
+		return nil, fmt.Errorf("HMAC signature verification failure: unknown key id %q", keyId)
+	}
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("HMAC signature verification failure: invalid timestamp %q", timestamp)
+	}
+	signedAt := time.Unix(sec, 0)
+	if skew := time.Since(signedAt).Abs(); skew > a.MaxSkew {
+		return nil, fmt.Errorf("HMAC signature verification failure: timestamp is outside of the allowed skew")
+	}
+
+	// read the body, and leave it readable for the handler
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("HMAC signature verification failure: unable to read body: %w", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	want := Sign(k.Secret, r.Method, r.URL.RequestURI(), timestamp, nonce, body)
+	got, err := hex.DecodeString(signature)
+	if err != nil || !stdhmac.Equal(got, want) {
+		return nil, fmt.Errorf("HMAC signature verification failure: invalid signature")
+	}
+
+	// a nonce can only be used once while its timestamp is valid
+	if !a.nonces.add(keyId+":"+nonce, signedAt.Add(a.MaxSkew)) {
+		return nil, fmt.Errorf("HMAC signature verification failure: nonce was already used")
+	}
+
+	claims := map[string]any{"sub": k.Id}
+	maps.Copy(claims, k.Claims)
+	return claims, nil
+}
+
+// Sign returns the HMAC-SHA256 signature of a request. The signed string is
+// the method, the path with the query, the timestamp, the nonce, and the hex
+// encoded SHA-256 hash of the body, separated by newlines.
+func Sign(secret, method, path, timestamp, nonce string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	mac := stdhmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s", method, path, timestamp, nonce, hex.EncodeToString(bodyHash[:]))
+	return mac.Sum(nil)
+}
+
+// nonceCache keeps the nonces that were used until they expire.
+type nonceCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// add returns false if the nonce was already used.
+func (c *nonceCache) add(nonce string, expiry time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for n, e := range c.seen {
+		if now.After(e) {
+			delete(c.seen, n)
+		}
+	}
+	if _, ok := c.seen[nonce]; ok {
+		return false
+	}
+	c.seen[nonce] = expiry
+	return true
+}
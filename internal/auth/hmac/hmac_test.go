@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hmac_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/hmac"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlHmac(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	authServices:
+		my-hmac:
+			kind: hmac
+			keys:
+				- id: partner-a
+					secret: shared-secret
+					claims:
+						tenant: acme
+			maxSkew: 2m
+	`
+	want := server.AuthServiceConfigs{
+		"my-hmac": hmac.Config{
+			Name: "my-hmac",
+			Kind: "hmac",
+			Keys: []hmac.Key{
+				{Id: "partner-a", Secret: "shared-secret", Claims: map[string]any{"tenant": "acme"}},
+			},
+			MaxSkew: "2m",
+		},
+	}
+	got := struct {
+		AuthServices server.AuthServiceConfigs `yaml:"authServices"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.AuthServices); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func newSignedRequest(secret, keyId string, signedAt time.Time, nonce, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/tool/my-tool/invoke?x=1", strings.NewReader(body))
+	ts := strconv.FormatInt(signedAt.Unix(), 10)
+	sig := hmac.Sign(secret, r.Method, r.URL.RequestURI(), ts, nonce, []byte(body))
+	r.Header.Set("my-hmac_key_id", keyId)
+	r.Header.Set("my-hmac_timestamp", ts)
+	r.Header.Set("my-hmac_nonce", nonce)
+	r.Header.Set("my-hmac_signature", hex.EncodeToString(sig))
+	return r
+}
+
+func TestGetClaimsFromRequest(t *testing.T) {
+	cfg := hmac.Config{
+		Name: "my-hmac",
+		Kind: hmac.AuthServiceKind,
+		Keys: []hmac.Key{
+			{Id: "partner-a", Secret: "shared-secret", Claims: map[string]any{"tenant": "acme"}},
+		},
+		MaxSkew: "1m",
+	}
+	aS, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	a := aS.(auth.RequestAuthService)
+	ctx := context.Background()
+	body := `{"id": 1}`
+
+	t.Run("valid signature", func(t *testing.T) {
+		r := newSignedRequest("shared-secret", "partner-a", time.Now(), "nonce-1", body)
+		got, err := a.GetClaimsFromRequest(ctx, r)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := map[string]any{"sub": "partner-a", "tenant": "acme"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("incorrect claims: diff %v", diff)
+		}
+		// the body must still be readable by the handler
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("unable to read body: %s", err)
+		}
+		if string(b) != body {
+			t.Fatalf("unexpected body: got %q, want %q", string(b), body)
+		}
+	})
+
+	t.Run("no signature", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/tool/my-tool/invoke", strings.NewReader(body))
+		got, err := a.GetClaimsFromRequest(ctx, r)
+		if err != nil || got != nil {
+			t.Fatalf("expected no claims and no error, got %v, %v", got, err)
+		}
+	})
+
+	t.Run("headers only", func(t *testing.T) {
+		r := newSignedRequest("shared-secret", "partner-a", time.Now(), "nonce-6", body)
+		got, err := a.GetClaimsFromHeader(ctx, r.Header)
+		if err == nil || !strings.Contains(err.Error(), "only the headers") {
+			t.Fatalf("expected a headers only error, got %v, %v", got, err)
+		}
+	})
+
+	tcs := []struct {
+		name string
+		r    func() *http.Request
+	}{
+		{
+			name: "wrong secret",
+			r: func() *http.Request {
+				return newSignedRequest("other-secret", "partner-a", time.Now(), "nonce-2", body)
+			},
+		},
+		{
+			name: "unknown key id",
+			r: func() *http.Request {
+				return newSignedRequest("shared-secret", "partner-b", time.Now(), "nonce-3", body)
+			},
+		},
+		{
+			name: "stale timestamp",
+			r: func() *http.Request {
+				return newSignedRequest("shared-secret", "partner-a", time.Now().Add(-5*time.Minute), "nonce-4", body)
+			},
+		},
+		{
+			name: "modified body",
+			r: func() *http.Request {
+				r := newSignedRequest("shared-secret", "partner-a", time.Now(), "nonce-5", body)
+				r.Body = io.NopCloser(strings.NewReader(`{"id": 2}`))
+				return r
+			},
+		},
+		{
+			name: "replayed nonce",
+			r: func() *http.Request {
+				return newSignedRequest("shared-secret", "partner-a", time.Now(), "nonce-1", body)
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.GetClaimsFromRequest(ctx, tc.r())
+			if err == nil {
+				t.Fatalf("expected error, got claims %v", got)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		name string
+		cfg  hmac.Config
+	}{
+		{
+			name: "no keys",
+			cfg:  hmac.Config{Name: "my-hmac", Kind: hmac.AuthServiceKind},
+		},
+		{
+			name: "duplicate key ids",
+			cfg: hmac.Config{
+				Name: "my-hmac",
+				Kind: hmac.AuthServiceKind,
+				Keys: []hmac.Key{{Id: "a", Secret: "x"}, {Id: "a", Secret: "y"}},
+			},
+		},
+		{
+			name: "invalid max skew",
+			cfg: hmac.Config{
+				Name:    "my-hmac",
+				Kind:    hmac.AuthServiceKind,
+				Keys:    []hmac.Key{{Id: "a", Secret: "x"}},
+				MaxSkew: "soon",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.cfg.Initialize(); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jwks verifies JWTs with the keys of a JSON Web Key Set.
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// minRefreshInterval is the minimum time between two fetches of a remote
+	// key set, so that tokens with unknown key ids can't be used to flood the
+	// issuer.
+	minRefreshInterval = time.Minute
+	// cacheDuration is how long a remote key set is cached before it is
+	// fetched again, so that keys removed by a rotation stop being trusted.
+	cacheDuration = time.Hour
+	// fetchTimeout bounds the requests of the default client, so that an
+	// unavailable issuer doesn't block the verification of tokens.
+	fetchTimeout = 10 * time.Second
+)
+
+// defaultClient is used to fetch remote key sets when no client is given.
+var defaultClient = &http.Client{Timeout: fetchTimeout}
+
+// DefaultAlgorithms are the signature algorithms accepted when none are
+// configured.
+var DefaultAlgorithms = []string{
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.EdDSA),
+}
+
+// KeySet is a JSON Web Key Set, read from a file or fetched from a URL.
+type KeySet struct {
+	url    string
+	client *http.Client
+	// issuer is set for key sets whose URL is discovered from the OpenID
+	// configuration of the issuer
+	issuer string
+	// discoveredUrl is the URL of the key set of issuer. It is only used by
+	// the fetch of group.
+	discoveredUrl string
+
+	// group shares a fetch of the key set between concurrent callers, so
+	// that it runs only once and without holding mu.
+	group     singleflight.Group
+	mu        sync.Mutex
+	keys      jose.JSONWebKeySet
+	lastFetch time.Time
+}
+
+// NewFileKeySet reads a key set from a local file.
+func NewFileKeySet(path string) (*KeySet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read JWKS file: %w", err)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return nil, fmt.Errorf("unable to parse JWKS file: %w", err)
+	}
+	return &KeySet{keys: keys}, nil
+}
+
+// NewRemoteKeySet returns a key set that is fetched from url when it is first
+// used, and fetched again when a token is signed by an unknown key.
+func NewRemoteKeySet(url string, client *http.Client) *KeySet {
+	if client == nil {
+		client = defaultClient
+	}
+	return &KeySet{url: url, client: client}
+}
+
+// NewIssuerKeySet returns a remote key set whose URL is discovered from the
+// OpenID Provider Metadata of issuer, at `/.well-known/openid-configuration`.
+func NewIssuerKeySet(issuer string, client *http.Client) *KeySet {
+	if client == nil {
+		client = defaultClient
+	}
+	return &KeySet{issuer: issuer, client: client}
+}
+
+// key returns the key with id kid. A token without a key id can only be
+// verified by a key set with a single key.
+func (k *KeySet) key(ctx context.Context, kid string) (*jose.JSONWebKey, error) {
+	k.mu.Lock()
+	keys, lastFetch := k.keys, k.lastFetch
+	k.mu.Unlock()
+
+	remote := k.url != "" || k.issuer != ""
+	expired := remote && time.Since(lastFetch) > cacheDuration
+	if key, ok := findKey(keys, kid); ok && !expired {
+		return key, nil
+	}
+	// the key may have been rotated since the last fetch
+	if remote && (expired || time.Since(lastFetch) >= minRefreshInterval) {
+		// the fetch is shared with other callers, so it must not be
+		// cancelled with the context of this one
+		_, err, _ := k.group.Do("", func() (any, error) {
+			return nil, k.refresh(context.WithoutCancel(ctx), lastFetch)
+		})
+		k.mu.Lock()
+		keys = k.keys
+		k.mu.Unlock()
+		if err != nil {
+			// keep the cached keys if the issuer is unavailable
+			if key, ok := findKey(keys, kid); ok {
+				return key, nil
+			}
+			return nil, err
+		}
+	}
+	if key, ok := findKey(keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no key found for key id %q", kid)
+}
+
+// refresh fetches the key set, unless it has been fetched since lastFetch.
+func (k *KeySet) refresh(ctx context.Context, lastFetch time.Time) error {
+	k.mu.Lock()
+	fetched := !k.lastFetch.Equal(lastFetch)
+	k.mu.Unlock()
+	if fetched {
+		return nil
+	}
+
+	keys, err := k.fetch(ctx)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.lastFetch = time.Now()
+	if err != nil {
+		return err
+	}
+	k.keys = keys
+	return nil
+}
+
+// discover fetches the URL of the key set from the OpenID Provider Metadata of
+// the issuer.
+func (k *KeySet) discover(ctx context.Context) (string, error) {
+	var metadata struct {
+		Issuer  string `json:"issuer"`
+		JwksUri string `json:"jwks_uri"`
+	}
+	u := strings.TrimSuffix(k.issuer, "/") + "/.well-known/openid-configuration"
+	if err := k.get(ctx, u, &metadata); err != nil {
+		return "", fmt.Errorf("unable to discover JWKS URL: %w", err)
+	}
+	if metadata.Issuer != k.issuer {
+		return "", fmt.Errorf("unable to discover JWKS URL: issuer %q does not match %q", metadata.Issuer, k.issuer)
+	}
+	if metadata.JwksUri == "" {
+		return "", fmt.Errorf("unable to discover JWKS URL: missing jwks_uri")
+	}
+	return metadata.JwksUri, nil
+}
+
+func (k *KeySet) fetch(ctx context.Context) (jose.JSONWebKeySet, error) {
+	var keys jose.JSONWebKeySet
+	u := k.url
+	if u == "" {
+		if k.discoveredUrl == "" {
+			discovered, err := k.discover(ctx)
+			if err != nil {
+				return keys, err
+			}
+			k.discoveredUrl = discovered
+		}
+		u = k.discoveredUrl
+	}
+	if err := k.get(ctx, u, &keys); err != nil {
+		return keys, fmt.Errorf("unable to fetch JWKS: %w", err)
+	}
+	return keys, nil
+}
+
+// get fetches the JSON document at u into v.
+func (k *KeySet) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func findKey(keys jose.JSONWebKeySet, kid string) (*jose.JSONWebKey, bool) {
+	if kid == "" {
+		if len(keys.Keys) == 1 {
+			return &keys.Keys[0], true
+		}
+		return nil, false
+	}
+	if found := keys.Key(kid); len(found) > 0 {
+		return &found[0], true
+	}
+	return nil, false
+}
+
+// Expected are the values a token is validated against.
+type Expected struct {
+	// Issuer matches the "iss" claim exactly, if set.
+	Issuer string
+	// Audiences match if one of them is in the "aud" claim, if set.
+	Audiences []string
+	// Algorithms are the accepted signature algorithms. DefaultAlgorithms
+	// are used if not set.
+	Algorithms []string
+	// Leeway is the clock skew allowed when validating the time claims.
+	Leeway time.Duration
+}
+
+// Verify verifies the signature of token with the key set and validates its
+// claims. It returns all claims of the token.
+func (k *KeySet) Verify(ctx context.Context, token string, expected Expected) (map[string]any, error) {
+	names := expected.Algorithms
+	if len(names) == 0 {
+		names = DefaultAlgorithms
+	}
+	algorithms := make([]jose.SignatureAlgorithm, 0, len(names))
+	for _, a := range names {
+		algorithms = append(algorithms, jose.SignatureAlgorithm(a))
+	}
+
+	tok, err := jwt.ParseSigned(token, algorithms)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if len(tok.Headers) != 1 {
+		return nil, fmt.Errorf("invalid token: expected a single signature")
+	}
+	key, err := k.key(ctx, tok.Headers[0].KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	var registered jwt.Claims
+	var claims map[string]any
+	if err := tok.Claims(key, &registered, &claims); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if registered.Expiry == nil {
+		return nil, fmt.Errorf("invalid token: missing expiration time")
+	}
+	e := jwt.Expected{Issuer: expected.Issuer, AnyAudience: expected.Audiences}
+	if err := registered.ValidateWithLeeway(e, expected.Leeway); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,334 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+func newKey(t *testing.T, kid string) jose.JSONWebKey {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	return jose.JSONWebKey{Key: k, KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func sign(t *testing.T, key jose.JSONWebKey, claims map[string]any) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		t.Fatalf("unable to create signer: %s", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatalf("unable to sign token: %s", err)
+	}
+	return token
+}
+
+func marshalKeySet(t *testing.T, keys ...jose.JSONWebKey) []byte {
+	t.Helper()
+	set := jose.JSONWebKeySet{}
+	for _, k := range keys {
+		set.Keys = append(set.Keys, k.Public())
+	}
+	b, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("unable to marshal key set: %s", err)
+	}
+	return b
+}
+
+func TestVerify(t *testing.T) {
+	key := newKey(t, "key-1")
+	path := filepath.Join(t.TempDir(), "json")
+	if err := os.WriteFile(path, marshalKeySet(t, key), 0o600); err != nil {
+		t.Fatalf("unable to write key set: %s", err)
+	}
+	keySet, err := NewFileKeySet(path)
+	if err != nil {
+		t.Fatalf("unable to read key set: %s", err)
+	}
+
+	now := time.Now()
+	valid := map[string]any{
+		"iss": "https://issuer.example.com",
+		"aud": "my-audience",
+		"sub": "alice",
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	with := func(k string, v any) map[string]any {
+		c := make(map[string]any)
+		for name, value := range valid {
+			c[name] = value
+		}
+		if v == nil {
+			delete(c, k)
+		} else {
+			c[k] = v
+		}
+		return c
+	}
+	expected := Expected{Issuer: "https://issuer.example.com", Audiences: []string{"my-audience"}}
+
+	tcs := []struct {
+		desc     string
+		token    string
+		expected Expected
+		wantErr  string
+	}{
+		{
+			desc:     "valid token",
+			token:    sign(t, key, valid),
+			expected: expected,
+		},
+		{
+			desc:     "wrong issuer",
+			token:    sign(t, key, with("iss", "https://other.example.com")),
+			expected: expected,
+			wantErr:  "invalid issuer",
+		},
+		{
+			desc:     "wrong audience",
+			token:    sign(t, key, with("aud", "other-audience")),
+			expected: expected,
+			wantErr:  "invalid audience",
+		},
+		{
+			desc:     "expired",
+			token:    sign(t, key, with("exp", now.Add(-time.Hour).Unix())),
+			expected: expected,
+			wantErr:  "token is expired",
+		},
+		{
+			desc:     "expired within leeway",
+			token:    sign(t, key, with("exp", now.Add(-time.Minute).Unix())),
+			expected: Expected{Issuer: expected.Issuer, Audiences: expected.Audiences, Leeway: 5 * time.Minute},
+		},
+		{
+			desc:     "missing expiration",
+			token:    sign(t, key, with("exp", nil)),
+			expected: expected,
+			wantErr:  "missing expiration time",
+		},
+		{
+			desc:     "unknown key",
+			token:    sign(t, newKey(t, "key-2"), valid),
+			expected: expected,
+			wantErr:  `no key found for key id "key-2"`,
+		},
+		{
+			desc:     "signature of another key",
+			token:    sign(t, newKey(t, "key-1"), valid),
+			expected: expected,
+			wantErr:  "error in cryptographic primitive",
+		},
+		{
+			desc:     "algorithm not allowed",
+			token:    sign(t, key, valid),
+			expected: Expected{Algorithms: []string{"ES256"}},
+			wantErr:  "unexpected signature algorithm",
+		},
+		{
+			desc:     "not a token",
+			token:    "foo",
+			expected: expected,
+			wantErr:  "invalid token",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			claims, err := keySet.Verify(context.Background(), tc.token, tc.expected)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if claims["sub"] != "alice" {
+				t.Fatalf("unexpected claims: %v", claims)
+			}
+		})
+	}
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	key, rotatedKey := newKey(t, "key-1"), newKey(t, "key-2")
+	var mu sync.Mutex
+	served := marshalKeySet(t, key)
+	fetches := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetches++
+		_, _ = w.Write(served)
+	}))
+	defer ts.Close()
+	wantFetches := func(want int) {
+		t.Helper()
+		mu.Lock()
+		defer mu.Unlock()
+		if fetches != want {
+			t.Fatalf("unexpected number of fetches: got %d, want %d", fetches, want)
+		}
+	}
+
+	keySet := NewRemoteKeySet(ts.URL, nil)
+	claims := map[string]any{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}
+
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// known keys are verified without fetching the key set again
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantFetches(1)
+
+	// the key set is not fetched again right away for an unknown key
+	mu.Lock()
+	served = marshalKeySet(t, key, rotatedKey)
+	mu.Unlock()
+	if _, err := keySet.Verify(context.Background(), sign(t, rotatedKey, claims), Expected{}); err == nil {
+		t.Fatalf("expected an error for a key that is not fetched yet")
+	}
+	wantFetches(1)
+
+	// rotated keys are fetched once the minimum refresh interval has passed
+	keySet.lastFetch = time.Now().Add(-minRefreshInterval)
+	if _, err := keySet.Verify(context.Background(), sign(t, rotatedKey, claims), Expected{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantFetches(2)
+
+	// keys removed from the key set stop being trusted once the cache expires
+	mu.Lock()
+	served = marshalKeySet(t, rotatedKey)
+	mu.Unlock()
+	keySet.lastFetch = time.Now().Add(-cacheDuration - time.Second)
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{}); err == nil {
+		t.Fatalf("expected an error for a removed key")
+	}
+	wantFetches(3)
+}
+
+func TestRemoteKeySetConcurrentFetch(t *testing.T) {
+	key, rotatedKey := newKey(t, "key-1"), newKey(t, "key-2")
+	var mu sync.Mutex
+	served := marshalKeySet(t, key)
+	fetches := 0
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetches++
+		n, b := fetches, served
+		mu.Unlock()
+		if n == 2 {
+			close(blocked)
+			<-release
+		}
+		_, _ = w.Write(b)
+	}))
+	defer ts.Close()
+
+	keySet := NewRemoteKeySet(ts.URL, nil)
+	claims := map[string]any{"sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mu.Lock()
+	served = marshalKeySet(t, key, rotatedKey)
+	mu.Unlock()
+	keySet.lastFetch = time.Now().Add(-minRefreshInterval)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keySet.Verify(context.Background(), sign(t, rotatedKey, claims), Expected{})
+			errs <- err
+		}()
+	}
+	<-blocked
+
+	// cached keys are verified while the key set is fetched
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// concurrent callers share a single fetch
+	mu.Lock()
+	defer mu.Unlock()
+	if fetches != 2 {
+		t.Fatalf("unexpected number of fetches: got %d, want 2", fetches)
+	}
+}
+
+func TestIssuerKeySet(t *testing.T) {
+	key := newKey(t, "key-1")
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"issuer": %q, "jwks_uri": %q}`, ts.URL, ts.URL+"/keys")
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(marshalKeySet(t, key))
+	})
+
+	claims := map[string]any{"iss": ts.URL, "sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}
+	keySet := NewIssuerKeySet(ts.URL, nil)
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{Issuer: ts.URL}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the issuer of the metadata must match the configured issuer exactly
+	keySet = NewIssuerKeySet(ts.URL+"/", nil)
+	if _, err := keySet.Verify(context.Background(), sign(t, key, claims), Expected{}); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
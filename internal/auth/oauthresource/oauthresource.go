@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauthresource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/jwks"
+)
+
+const AuthServiceKind string = "oauth-resource"
+
+// wellKnownPath is the well-known URI of the OAuth 2.0 Protected Resource
+// Metadata.
+const wellKnownPath = "/.well-known/oauth-protected-resource"
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name                string   `yaml:"name" validate:"required"`
+	Kind                string   `yaml:"kind" validate:"required"`
+	Resource            string   `yaml:"resource" validate:"required"`
+	AuthorizationServer string   `yaml:"authorizationServer" validate:"required"`
+	Audience            string   `yaml:"audience"`
+	JwksUri             string   `yaml:"jwksUri"`
+	JwksFile            string   `yaml:"jwksFile"`
+	Scopes              []string `yaml:"scopes"`
+}
+
+// Returns the auth service kind
+func (cfg Config) AuthServiceConfigKind() string {
+	return AuthServiceKind
+}
+
+// Initialize an OAuth 2.0 protected resource auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	resource, err := url.Parse(cfg.Resource)
+	if err != nil || !resource.IsAbs() || resource.Host == "" {
+		return nil, fmt.Errorf("resource must be an absolute URL: %q", cfg.Resource)
+	}
+
+	var keys *jwks.KeySet
+	switch {
+	case cfg.JwksUri != "" && cfg.JwksFile != "":
+		return nil, fmt.Errorf("only one of jwksUri and jwksFile can be set")
+	case cfg.JwksUri != "":
+		keys = jwks.NewRemoteKeySet(cfg.JwksUri, nil)
+	case cfg.JwksFile != "":
+		keys, err = jwks.NewFileKeySet(cfg.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("one of jwksUri and jwksFile must be set")
+	}
+
+	audience := cfg.Audience
+	if audience == "" {
+		audience = cfg.Resource
+	}
+
+	// the metadata of a resource with a path is served under the well-known
+	// URI followed by the path of the resource
+	metadataPath := wellKnownPath + strings.TrimSuffix(resource.Path, "/")
+
+	a := &AuthService{
+		Name:                cfg.Name,
+		Kind:                AuthServiceKind,
+		Resource:            cfg.Resource,
+		AuthorizationServer: cfg.AuthorizationServer,
+		Audience:            audience,
+		Scopes:              cfg.Scopes,
+		keys:                keys,
+		metadataPath:        metadataPath,
+	}
+	return a, nil
+}
+
+var _ auth.ProtectedResource = AuthService{}
+var _ auth.TokenAuthService = AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Name                string   `yaml:"name"`
+	Kind                string   `yaml:"kind"`
+	Resource            string   `yaml:"resource"`
+	AuthorizationServer string   `yaml:"authorizationServer"`
+	Audience            string   `yaml:"audience"`
+	Scopes              []string `yaml:"scopes"`
+
+	keys         *jwks.KeySet
+	metadataPath string
+}
+
+// Returns the auth service kind
+func (a AuthService) AuthServiceKind() string {
+	return AuthServiceKind
+}
+
+// Returns the name of the auth service
+func (a AuthService) GetName() string {
+	return a.Name
+}
+
+// Returns the bearer access token of the `Authorization` header
+func (a AuthService) GetTokenFromHeader(h http.Header) string {
+	token, ok := strings.CutPrefix(h.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
+
+// Verifies the bearer access token in the `Authorization` header and returns
+// its claims
+func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	token := a.GetTokenFromHeader(h)
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := a.keys.Verify(ctx, token, jwks.Expected{
+		Issuer:    a.AuthorizationServer,
+		Audiences: []string{a.Audience},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("access token verification failure: %w", err)
+	}
+	granted := tokenScopes(claims)
+	for _, scope := range a.Scopes {
+		if !slices.Contains(granted, scope) {
+			return nil, fmt.Errorf("%w: missing scope %q", auth.ErrInsufficientScope, scope)
+		}
+	}
+	return claims, nil
+}
+
+// tokenScopes returns the scopes granted by an access token, either in the
+// space-separated `scope` claim (RFC 9068) or in the `scp` claim.
+func tokenScopes(claims map[string]any) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	var scopes []string
+	switch scp := claims["scp"].(type) {
+	case string:
+		scopes = strings.Fields(scp)
+	case []any:
+		for _, s := range scp {
+			if s, ok := s.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	return scopes
+}
+
+// Returns the OAuth 2.0 Protected Resource Metadata of the MCP endpoint
+func (a AuthService) ResourceMetadata() auth.ProtectedResourceMetadata {
+	return auth.ProtectedResourceMetadata{
+		Resource:               a.Resource,
+		AuthorizationServers:   []string{a.AuthorizationServer},
+		ScopesSupported:        a.Scopes,
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           a.Name,
+	}
+}
+
+// Returns the path where the metadata is served
+func (a AuthService) ResourceMetadataPath() string {
+	return a.metadataPath
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauthresource_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/auth/oauthresource"
+)
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  oauthresource.Config
+		err  string
+	}{
+		{
+			desc: "relative resource",
+			cfg:  oauthresource.Config{Resource: "/mcp", AuthorizationServer: "https://auth.example.com", JwksUri: "https://auth.example.com/jwks"},
+			err:  `resource must be an absolute URL: "/mcp"`,
+		},
+		{
+			desc: "missing key set",
+			cfg:  oauthresource.Config{Resource: "https://toolbox.example.com/mcp", AuthorizationServer: "https://auth.example.com"},
+			err:  "one of jwksUri and jwksFile must be set",
+		},
+		{
+			desc: "both key sets",
+			cfg:  oauthresource.Config{Resource: "https://toolbox.example.com/mcp", AuthorizationServer: "https://auth.example.com", JwksUri: "https://auth.example.com/jwks", JwksFile: "jwks.json"},
+			err:  "only one of jwksUri and jwksFile can be set",
+		},
+		{
+			desc: "missing key set file",
+			cfg:  oauthresource.Config{Resource: "https://toolbox.example.com/mcp", AuthorizationServer: "https://auth.example.com", JwksFile: "does-not-exist.json"},
+			err:  "unable to read JWKS file",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
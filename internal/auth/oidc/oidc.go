@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/jwks"
+)
+
+const AuthServiceKind string = "oidc"
+
+const (
+	// HeaderToken reads the token from the `<name>_token` header.
+	HeaderToken = "token"
+	// HeaderAuthorization reads the token from the `Authorization: Bearer`
+	// header.
+	HeaderAuthorization = "authorization"
+)
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name       string   `yaml:"name" validate:"required"`
+	Kind       string   `yaml:"kind" validate:"required"`
+	Issuer     string   `yaml:"issuer" validate:"required"`
+	Audiences  []string `yaml:"audiences"`
+	JwksUri    string   `yaml:"jwksUri"`
+	JwksFile   string   `yaml:"jwksFile"`
+	Algorithms []string `yaml:"algorithms"`
+	ClockSkew  string   `yaml:"clockSkew"`
+	Header     string   `yaml:"header"`
+}
+
+// Returns the auth service kind
+func (cfg Config) AuthServiceConfigKind() string {
+	return AuthServiceKind
+}
+
+// Initialize an OIDC auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	var clockSkew time.Duration
+	if cfg.ClockSkew != "" {
+		var err error
+		clockSkew, err = time.ParseDuration(cfg.ClockSkew)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse clockSkew string as time.Duration: %s", err)
+		}
+	}
+
+	header := cfg.Header
+	switch header {
+	case "":
+		header = HeaderToken
+	case HeaderToken, HeaderAuthorization:
+	default:
+		return nil, fmt.Errorf("invalid header %q: must be one of %q", header, []string{HeaderToken, HeaderAuthorization})
+	}
+
+	var keys *jwks.KeySet
+	switch {
+	case cfg.JwksUri != "" && cfg.JwksFile != "":
+		return nil, fmt.Errorf("only one of jwksUri and jwksFile can be set")
+	case cfg.JwksUri != "":
+		keys = jwks.NewRemoteKeySet(cfg.JwksUri, nil)
+	case cfg.JwksFile != "":
+		var err error
+		keys, err = jwks.NewFileKeySet(cfg.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		// the key set is discovered from the issuer
+		keys = jwks.NewIssuerKeySet(cfg.Issuer, nil)
+	}
+
+	a := &AuthService{
+		Name:       cfg.Name,
+		Kind:       AuthServiceKind,
+		Issuer:     cfg.Issuer,
+		Audiences:  cfg.Audiences,
+		Algorithms: cfg.Algorithms,
+		ClockSkew:  clockSkew,
+		Header:     header,
+		keys:       keys,
+	}
+	return a, nil
+}
+
+var _ auth.TokenAuthService = AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Name       string        `yaml:"name"`
+	Kind       string        `yaml:"kind"`
+	Issuer     string        `yaml:"issuer"`
+	Audiences  []string      `yaml:"audiences"`
+	Algorithms []string      `yaml:"algorithms"`
+	ClockSkew  time.Duration `yaml:"clockSkew"`
+	Header     string        `yaml:"header"`
+
+	keys *jwks.KeySet
+}
+
+// Returns the auth service kind
+func (a AuthService) AuthServiceKind() string {
+	return AuthServiceKind
+}
+
+// Returns the name of the auth service
+func (a AuthService) GetName() string {
+	return a.Name
+}
+
+// Returns the token sent in the configured header
+func (a AuthService) GetTokenFromHeader(h http.Header) string {
+	if a.Header == HeaderAuthorization {
+		token, _ := strings.CutPrefix(h.Get("Authorization"), "Bearer ")
+		return token
+	}
+	return h.Get(a.Name + "_token")
+}
+
+// Verifies the JWT and returns its claims
+func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	token := a.GetTokenFromHeader(h)
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := a.keys.Verify(ctx, token, jwks.Expected{
+		Issuer:     a.Issuer,
+		Audiences:  a.Audiences,
+		Algorithms: a.Algorithms,
+		Leeway:     a.ClockSkew,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OIDC token verification failure: %w", err)
+	}
+	return claims, nil
+}
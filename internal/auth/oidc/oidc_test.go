@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth/oidc"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlOidc(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	authServices:
+		my-oidc:
+			kind: oidc
+			issuer: https://example.okta.com
+			audiences:
+				- api://toolbox
+			algorithms:
+				- RS256
+			clockSkew: 30s
+			header: authorization
+	`
+	want := server.AuthServiceConfigs{
+		"my-oidc": oidc.Config{
+			Name:       "my-oidc",
+			Kind:       "oidc",
+			Issuer:     "https://example.okta.com",
+			Audiences:  []string{"api://toolbox"},
+			Algorithms: []string{"RS256"},
+			ClockSkew:  "30s",
+			Header:     "authorization",
+		},
+	}
+	got := struct {
+		AuthServices server.AuthServiceConfigs `yaml:"authServices"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.AuthServices); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	key := jose.JSONWebKey{Key: k, KeyID: "key-1", Algorithm: string(jose.RS256), Use: "sig"}
+	b, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key.Public()}})
+	if err != nil {
+		t.Fatalf("unable to marshal key set: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatalf("unable to write key set: %s", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	if err != nil {
+		t.Fatalf("unable to create signer: %s", err)
+	}
+	token, err := jwt.Signed(signer).Claims(map[string]any{
+		"iss": "https://example.okta.com",
+		"aud": "api://toolbox",
+		"sub": "alice",
+		"exp": time.Now().Add(-10 * time.Second).Unix(),
+	}).Serialize()
+	if err != nil {
+		t.Fatalf("unable to sign token: %s", err)
+	}
+
+	cfg := oidc.Config{
+		Name:      "my-oidc",
+		Kind:      "oidc",
+		Issuer:    "https://example.okta.com",
+		Audiences: []string{"api://toolbox"},
+		JwksFile:  path,
+		ClockSkew: "1m",
+	}
+	tcs := []struct {
+		desc    string
+		header  string
+		h       http.Header
+		wantSub any
+		wantErr string
+	}{
+		{
+			desc:    "token header",
+			h:       http.Header{"My-Oidc_token": []string{token}},
+			wantSub: "alice",
+		},
+		{
+			desc:   "token header ignores the authorization header",
+			h:      http.Header{"Authorization": []string{"Bearer " + token}},
+			header: oidc.HeaderToken,
+		},
+		{
+			desc:    "authorization header",
+			header:  oidc.HeaderAuthorization,
+			h:       http.Header{"Authorization": []string{"Bearer " + token}},
+			wantSub: "alice",
+		},
+		{
+			desc:    "invalid token",
+			h:       http.Header{"My-Oidc_token": []string{"foo"}},
+			wantErr: "OIDC token verification failure",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := cfg
+			c.Header = tc.header
+			a, err := c.Initialize()
+			if err != nil {
+				t.Fatalf("unable to initialize auth service: %s", err)
+			}
+			claims, err := a.GetClaimsFromHeader(context.Background(), tc.h)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if claims["sub"] != tc.wantSub {
+				t.Fatalf("unexpected claims: %v", claims)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  oidc.Config
+		err  string
+	}{
+		{
+			desc: "invalid header",
+			cfg:  oidc.Config{Issuer: "https://example.okta.com", Header: "cookie"},
+			err:  `invalid header "cookie"`,
+		},
+		{
+			desc: "invalid clock skew",
+			cfg:  oidc.Config{Issuer: "https://example.okta.com", ClockSkew: "foo"},
+			err:  "unable to parse clockSkew string as time.Duration",
+		},
+		{
+			desc: "both key sets",
+			cfg:  oidc.Config{Issuer: "https://example.okta.com", JwksUri: "https://example.okta.com/keys", JwksFile: "jwks.json"},
+			err:  "only one of jwksUri and jwksFile can be set",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Config is the configuration of a prompt in the `prompts` section of the
+// tools file.
+type Config struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description"`
+	Arguments   tools.Parameters `yaml:"arguments"`
+	Messages    []MessageConfig  `yaml:"messages" validate:"required"`
+}
+
+// MessageConfig is a single templated message of a prompt.
+type MessageConfig struct {
+	Role string `yaml:"role"`
+	Text string `yaml:"text" validate:"required"`
+}
+
+// Initialize validates the prompt config and parses its message templates.
+func (cfg Config) Initialize() (Prompt, error) {
+	p := Prompt{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		Arguments:   cfg.Arguments,
+	}
+	if !tools.IsValidName(cfg.Name) {
+		return p, fmt.Errorf("invalid prompt name: %s", cfg.Name)
+	}
+	if len(cfg.Messages) == 0 {
+		return p, fmt.Errorf("prompt must have at least one message")
+	}
+	for _, a := range cfg.Arguments {
+		// prompts are not tied to an authenticated request
+		if len(a.GetAuthServices()) != 0 {
+			return p, fmt.Errorf("prompt argument %q should not have auth services", a.GetName())
+		}
+	}
+
+	for i, m := range cfg.Messages {
+		role := m.Role
+		if role == "" {
+			role = RoleUser
+		}
+		if role != RoleUser && role != RoleAssistant {
+			return p, fmt.Errorf("invalid role %q for message #%d: must be one of %q or %q", m.Role, i, RoleUser, RoleAssistant)
+		}
+		t, err := template.New(fmt.Sprintf("%s-%d", cfg.Name, i)).Option("missingkey=error").Parse(m.Text)
+		if err != nil {
+			return p, fmt.Errorf("unable to parse template for message #%d: %w", i, err)
+		}
+		p.messages = append(p.messages, messageTemplate{role: role, template: t})
+	}
+	return p, nil
+}
+
+type messageTemplate struct {
+	role     string
+	template *template.Template
+}
+
+// Prompt is a named, parameterized prompt template that MCP clients can fetch
+// with `prompts/get`.
+type Prompt struct {
+	Name        string
+	Description string
+	Arguments   tools.Parameters
+
+	messages []messageTemplate
+}
+
+// Message is a rendered message of a prompt.
+type Message struct {
+	Role string
+	Text string
+}
+
+// Render parses the arguments provided by the client and executes the message
+// templates with them.
+func (p Prompt) Render(args map[string]string) ([]Message, error) {
+	data := make(map[string]any)
+	for _, a := range p.Arguments {
+		v, ok := args[a.GetName()]
+		if !ok {
+			continue
+		}
+		newV, err := tools.ConvertStringToParamValue(a.GetType(), v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse value for %q: %w", a.GetName(), err)
+		}
+		data[a.GetName()] = newV
+	}
+	params, err := tools.ParseParams(p.Arguments, data, map[string]map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+	values := params.AsMap()
+
+	messages := make([]Message, 0, len(p.messages))
+	for _, m := range p.messages {
+		var text bytes.Buffer
+		if err := m.template.Execute(&text, values); err != nil {
+			return nil, fmt.Errorf("unable to render prompt %q: %w", p.Name, err)
+		}
+		messages = append(messages, Message{Role: m.role, Text: text.String()})
+	}
+	return messages, nil
+}
+
+// McpManifest returns the MCP definition of the prompt.
+func (p Prompt) McpManifest() McpManifest {
+	args := make([]McpArgument, 0, len(p.Arguments))
+	for _, a := range p.Arguments {
+		args = append(args, McpArgument{
+			Name:        a.GetName(),
+			Description: a.Manifest().Description,
+			Required:    a.GetRequired(),
+		})
+	}
+	return McpManifest{
+		Name:        p.Name,
+		Description: p.Description,
+		Arguments:   args,
+	}
+}
+
+// McpManifest is a prompt or prompt template that the server offers.
+type McpManifest struct {
+	// The name of the prompt or prompt template.
+	Name string `json:"name"`
+	// An optional description of what this prompt provides.
+	Description string `json:"description,omitempty"`
+	// A list of arguments to use for templating the prompt.
+	Arguments []McpArgument `json:"arguments,omitempty"`
+}
+
+// McpArgument describes an argument that a prompt can accept.
+type McpArgument struct {
+	// The name of the argument.
+	Name string `json:"name"`
+	// A human-readable description of the argument.
+	Description string `json:"description,omitempty"`
+	// Whether this argument must be provided.
+	Required bool `json:"required,omitempty"`
+}
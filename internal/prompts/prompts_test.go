@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestRender(t *testing.T) {
+	cfg := prompts.Config{
+		Name: "analyze_table",
+		Arguments: tools.Parameters{
+			tools.NewStringParameter("table", "table name"),
+			tools.NewIntParameterWithDefault("limit", 10, "row limit"),
+			tools.NewBooleanParameterWithDefault("verbose", false, "explain each step"),
+		},
+		Messages: []prompts.MessageConfig{
+			{Text: "Analyze {{.limit}} rows of {{.table}}.{{if .verbose}} Explain each step.{{end}}"},
+			{Role: "assistant", Text: "Reading {{.table}}."},
+		},
+	}
+	p, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		args map[string]string
+		want []prompts.Message
+	}{
+		{
+			desc: "defaults",
+			args: map[string]string{"table": "orders"},
+			want: []prompts.Message{
+				{Role: "user", Text: "Analyze 10 rows of orders."},
+				{Role: "assistant", Text: "Reading orders."},
+			},
+		},
+		{
+			desc: "all arguments",
+			args: map[string]string{"table": "orders", "limit": "3", "verbose": "true"},
+			want: []prompts.Message{
+				{Role: "user", Text: "Analyze 3 rows of orders. Explain each step."},
+				{Role: "assistant", Text: "Reading orders."},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := p.Render(tc.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect messages: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailRender(t *testing.T) {
+	p, err := prompts.Config{
+		Name: "analyze_table",
+		Arguments: tools.Parameters{
+			tools.NewStringParameter("table", "table name"),
+			tools.NewIntParameterWithDefault("limit", 10, "row limit"),
+		},
+		Messages: []prompts.MessageConfig{{Text: "Analyze {{.limit}} rows of {{.table}}."}},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		args map[string]string
+		err  string
+	}{
+		{
+			desc: "missing required argument",
+			args: map[string]string{},
+			err:  `parameter "table" is required`,
+		},
+		{
+			desc: "invalid integer",
+			args: map[string]string{"table": "orders", "limit": "ten"},
+			err:  `"limit"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := p.Render(tc.args)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  prompts.Config
+		err  string
+	}{
+		{
+			desc: "invalid name",
+			cfg:  prompts.Config{Name: "a b", Messages: []prompts.MessageConfig{{Text: "t"}}},
+			err:  "invalid prompt name: a b",
+		},
+		{
+			desc: "no messages",
+			cfg:  prompts.Config{Name: "p"},
+			err:  "prompt must have at least one message",
+		},
+		{
+			desc: "invalid role",
+			cfg:  prompts.Config{Name: "p", Messages: []prompts.MessageConfig{{Role: "system", Text: "t"}}},
+			err:  `invalid role "system" for message #0`,
+		},
+		{
+			desc: "invalid template",
+			cfg:  prompts.Config{Name: "p", Messages: []prompts.MessageConfig{{Text: "{{.foo"}}},
+			err:  "unable to parse template for message #0",
+		},
+		{
+			desc: "argument with auth services",
+			cfg: prompts.Config{
+				Name: "p",
+				Arguments: tools.Parameters{
+					tools.NewStringParameterWithAuth("email", "user email", []tools.ParamAuthService{{Name: "my-google-auth", Field: "email"}}),
+				},
+				Messages: []prompts.MessageConfig{{Text: "t"}},
+			},
+			err: `prompt argument "email" should not have auth services`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
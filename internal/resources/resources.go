@@ -0,0 +1,298 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"strings"
+
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	mimeTypeJSON = "application/json"
+	mimeTypeText = "text/plain"
+)
+
+// templateVar matches a simple RFC 6570 expression, e.g. `{table_name}`.
+var templateVar = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+
+// Config is the configuration of a resource in the `resources` section of the
+// tools file. A resource either has a fixed `uri` or a `uriTemplate`, and its
+// content either comes from invoking a `tool` or from static `text`.
+type Config struct {
+	Name        string         `yaml:"name"`
+	URI         string         `yaml:"uri"`
+	URITemplate string         `yaml:"uriTemplate"`
+	Description string         `yaml:"description"`
+	MimeType    string         `yaml:"mimeType"`
+	Tool        string         `yaml:"tool"`
+	Arguments   map[string]any `yaml:"arguments"`
+	Text        string         `yaml:"text"`
+}
+
+// Initialize validates the resource config and binds it to its tool.
+func (cfg Config) Initialize(toolsMap map[string]tools.Tool) (Resource, error) {
+	r := Resource{
+		Name:        cfg.Name,
+		URI:         cfg.URI,
+		URITemplate: cfg.URITemplate,
+		Description: cfg.Description,
+		MimeType:    cfg.MimeType,
+		Arguments:   cfg.Arguments,
+		Text:        cfg.Text,
+		ToolName:    cfg.Tool,
+	}
+	if !tools.IsValidName(cfg.Name) {
+		return r, fmt.Errorf("invalid resource name: %s", cfg.Name)
+	}
+
+	if (cfg.URI == "") == (cfg.URITemplate == "") {
+		return r, fmt.Errorf("exactly one of `uri` or `uriTemplate` must be set")
+	}
+	if cfg.URITemplate != "" {
+		pattern, vars, err := compileTemplate(cfg.URITemplate)
+		if err != nil {
+			return r, err
+		}
+		r.pattern = pattern
+		r.vars = vars
+	}
+
+	if (cfg.Tool == "") == (cfg.Text == "") {
+		return r, fmt.Errorf("exactly one of `tool` or `text` must be set")
+	}
+	if cfg.Tool != "" {
+		tool, ok := toolsMap[cfg.Tool]
+		if !ok {
+			return r, fmt.Errorf("tool does not exist: %s", cfg.Tool)
+		}
+		r.tool = tool
+		r.paramTypes = make(map[string]string)
+		for _, p := range tool.Manifest().Parameters {
+			r.paramTypes[p.Name] = p.Type
+		}
+		for _, v := range r.vars {
+			if _, ok := r.paramTypes[v]; !ok {
+				return r, fmt.Errorf("uriTemplate variable %q is not a parameter of tool %q", v, cfg.Tool)
+			}
+		}
+	}
+
+	if r.MimeType == "" {
+		if r.tool != nil {
+			r.MimeType = mimeTypeJSON
+		} else {
+			r.MimeType = mimeTypeText
+		}
+	}
+	return r, nil
+}
+
+// compileTemplate converts a URI template into a regular expression that
+// captures each template variable from a concrete URI.
+func compileTemplate(uriTemplate string) (*regexp.Regexp, []string, error) {
+	var b strings.Builder
+	var vars []string
+	b.WriteString("^")
+	last := 0
+	for _, m := range templateVar.FindAllStringSubmatchIndex(uriTemplate, -1) {
+		b.WriteString(regexp.QuoteMeta(uriTemplate[last:m[0]]))
+		b.WriteString(`([^/?#]+)`)
+		vars = append(vars, uriTemplate[m[2]:m[3]])
+		last = m[1]
+	}
+	b.WriteString(regexp.QuoteMeta(uriTemplate[last:]))
+	b.WriteString("$")
+	if len(vars) == 0 {
+		return nil, nil, fmt.Errorf("uriTemplate %q has no variables, use `uri` instead", uriTemplate)
+	}
+	if strings.ContainsAny(templateVar.ReplaceAllString(uriTemplate, ""), "{}") {
+		return nil, nil, fmt.Errorf("uriTemplate %q only supports simple `{name}` expressions", uriTemplate)
+	}
+	pattern, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to compile uriTemplate %q: %w", uriTemplate, err)
+	}
+	return pattern, vars, nil
+}
+
+// Resource is a piece of read-only context that MCP clients can fetch with
+// `resources/read`.
+type Resource struct {
+	Name        string
+	URI         string
+	URITemplate string
+	Description string
+	MimeType    string
+	Arguments   map[string]any
+	Text        string
+	// ToolName is the name of the tool that backs the resource, if any.
+	ToolName string
+
+	tool       tools.Tool
+	paramTypes map[string]string
+	pattern    *regexp.Regexp
+	vars       []string
+}
+
+// IsTemplate returns true if the resource is defined by a URI template.
+func (r Resource) IsTemplate() bool {
+	return r.URITemplate != ""
+}
+
+// Match reports whether the uri refers to this resource. For templated
+// resources, the values of the template variables are also returned.
+func (r Resource) Match(uri string) (map[string]string, bool) {
+	if !r.IsTemplate() {
+		return nil, uri == r.URI
+	}
+	m := r.pattern.FindStringSubmatch(uri)
+	if m == nil {
+		return nil, false
+	}
+	values := make(map[string]string, len(r.vars))
+	for i, v := range r.vars {
+		value, err := url.PathUnescape(m[i+1])
+		if err != nil {
+			return nil, false
+		}
+		values[v] = value
+	}
+	return values, true
+}
+
+// Authorize returns an error if the caller can't read the resource through
+// toolset. A resource backed by a tool is only available in the toolsets that
+// include the tool, and to the callers that are allowed to invoke it.
+func (r Resource) Authorize(toolset tools.Toolset, authorizer *tools.Authorizer, claimsFromAuth map[string]map[string]any) error {
+	if r.tool == nil {
+		return nil
+	}
+	if _, ok := toolset.Manifest.ToolsManifest[r.ToolName]; !ok {
+		return fmt.Errorf("tool %q is not part of toolset %q", r.ToolName, toolset.Name)
+	}
+	if !r.tool.Authorized(mcputil.VerifiedAuthServices(claimsFromAuth)) {
+		return fmt.Errorf("unauthorized Tool call: `authRequired` is set for the target Tool")
+	}
+	return authorizer.Authorize(toolset.Name, r.ToolName, claimsFromAuth)
+}
+
+// Visible returns the resources that the caller can read through toolset.
+func Visible(resourcesMap map[string]Resource, toolset tools.Toolset, authorizer *tools.Authorizer, claimsFromAuth map[string]map[string]any) map[string]Resource {
+	visible := make(map[string]Resource, len(resourcesMap))
+	for name, r := range resourcesMap {
+		if r.Authorize(toolset, authorizer, claimsFromAuth) == nil {
+			visible[name] = r
+		}
+	}
+	return visible
+}
+
+// Read returns the text content of the resource at uri.
+func (r Resource) Read(ctx context.Context, uri string) (string, error) {
+	values, ok := r.Match(uri)
+	if !ok {
+		return "", fmt.Errorf("uri %q does not match resource %q", uri, r.Name)
+	}
+	if r.tool == nil {
+		return r.Text, nil
+	}
+
+	data := make(map[string]any)
+	maps.Copy(data, r.Arguments)
+	for k, v := range values {
+		parsed, err := tools.ConvertStringToParamValue(r.paramTypes[k], v)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse value for %q: %w", k, err)
+		}
+		data[k] = parsed
+	}
+
+	// the tool runs with the claims of the caller, like in `tools/call`
+	claimsFromAuth := util.AuthClaimsFromContext(ctx)
+	params, err := r.tool.ParseParams(data, claimsFromAuth)
+	if err != nil {
+		return "", fmt.Errorf("provided parameters were invalid: %w", err)
+	}
+	if !r.tool.Authorized(mcputil.VerifiedAuthServices(claimsFromAuth)) {
+		return "", fmt.Errorf("unauthorized Tool call: `authRequired` is set for the target Tool")
+	}
+	results, err := r.tool.Invoke(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	if results == nil {
+		results = []any{}
+	}
+	text, err := json.Marshal(results)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal result: %w", err)
+	}
+	return string(text), nil
+}
+
+// McpManifest returns the MCP definition of a resource with a fixed uri.
+func (r Resource) McpManifest() McpManifest {
+	return McpManifest{
+		URI:         r.URI,
+		Name:        r.Name,
+		Description: r.Description,
+		MimeType:    r.MimeType,
+	}
+}
+
+// McpTemplateManifest returns the MCP definition of a resource template.
+func (r Resource) McpTemplateManifest() McpTemplateManifest {
+	return McpTemplateManifest{
+		URITemplate: r.URITemplate,
+		Name:        r.Name,
+		Description: r.Description,
+		MimeType:    r.MimeType,
+	}
+}
+
+// McpManifest is a known resource that the server is capable of reading.
+type McpManifest struct {
+	// The URI of this resource.
+	URI string `json:"uri"`
+	// A human-readable name for this resource.
+	Name string `json:"name"`
+	// A description of what this resource represents.
+	Description string `json:"description,omitempty"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// McpTemplateManifest is a template description for resources available on
+// the server.
+type McpTemplateManifest struct {
+	// A URI template (according to RFC 6570) that can be used to construct
+	// resource URIs.
+	URITemplate string `json:"uriTemplate"`
+	// A human-readable name for the type of resource this template refers to.
+	Name string `json:"name"`
+	// A description of what this template is for.
+	Description string `json:"description,omitempty"`
+	// The MIME type for all resources that match this template.
+	MimeType string `json:"mimeType,omitempty"`
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/resources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+var _ tools.Tool = fakeTool{}
+
+// fakeTool returns the values of its parsed parameters when invoked.
+type fakeTool struct {
+	params       tools.Parameters
+	authRequired bool
+}
+
+func (t fakeTool) Invoke(_ context.Context, params tools.ParamValues) ([]any, error) {
+	return []any{params.AsMap()}, nil
+}
+
+func (t fakeTool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.params, data, claims)
+}
+
+func (t fakeTool) Manifest() tools.Manifest {
+	return tools.Manifest{Parameters: t.params.Manifest()}
+}
+
+func (t fakeTool) McpManifest() tools.McpManifest {
+	return tools.McpManifest{}
+}
+
+func (t fakeTool) Authorized(verifiedAuthServices []string) bool {
+	return !t.authRequired || len(verifiedAuthServices) > 0
+}
+
+func toolsMap() map[string]tools.Tool {
+	return map[string]tools.Tool{
+		"get_table": fakeTool{params: tools.Parameters{
+			tools.NewStringParameter("schema", "schema name"),
+			tools.NewStringParameter("table", "table name"),
+			tools.NewIntParameterWithDefault("limit", 10, "row limit"),
+		}},
+		"get_secret": fakeTool{authRequired: true},
+	}
+}
+
+func TestRead(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  resources.Config
+		uri  string
+		want string
+	}{
+		{
+			desc: "text",
+			cfg:  resources.Config{Name: "notes", URI: "toolbox://notes", Text: "hello"},
+			uri:  "toolbox://notes",
+			want: "hello",
+		},
+		{
+			desc: "tool with arguments",
+			cfg: resources.Config{
+				Name:      "orders",
+				URI:       "postgres://public/orders",
+				Tool:      "get_table",
+				Arguments: map[string]any{"schema": "public", "table": "orders"},
+			},
+			uri:  "postgres://public/orders",
+			want: `[{"limit":10,"schema":"public","table":"orders"}]`,
+		},
+		{
+			desc: "tool with template",
+			cfg: resources.Config{
+				Name:        "table",
+				URITemplate: "postgres://{schema}/{table}?limit={limit}",
+				Tool:        "get_table",
+			},
+			uri:  "postgres://sales/orders?limit=5",
+			want: `[{"limit":5,"schema":"sales","table":"orders"}]`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := tc.cfg.Initialize(toolsMap())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := r.Read(context.Background(), tc.uri)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect content: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	r, err := resources.Config{
+		Name:        "table",
+		URITemplate: "postgres://{schema}/{table}/schema",
+		Text:        "ddl",
+	}.Initialize(toolsMap())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		uri    string
+		wantOk bool
+		want   map[string]string
+	}{
+		{uri: "postgres://public/orders/schema", wantOk: true, want: map[string]string{"schema": "public", "table": "orders"}},
+		{uri: "postgres://my%20schema/a%2Fb/schema", wantOk: true, want: map[string]string{"schema": "my schema", "table": "a/b"}},
+		{uri: "postgres://public/%zz/schema", wantOk: false},
+		{uri: "postgres://public/orders", wantOk: false},
+		{uri: "postgres://public/a/b/schema", wantOk: false},
+		{uri: "mysql://public/orders/schema", wantOk: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.uri, func(t *testing.T) {
+			got, ok := r.Match(tc.uri)
+			if ok != tc.wantOk {
+				t.Fatalf("unexpected match result: got %t, want %t", ok, tc.wantOk)
+			}
+			if diff := cmp.Diff(tc.want, got); ok && diff != "" {
+				t.Fatalf("incorrect variables: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	toolsMap := toolsMap()
+	toolsetConfigs := map[string][]string{
+		"":       {"get_table", "get_secret"},
+		"tables": {"get_table"},
+	}
+	toolsets := make(map[string]tools.Toolset)
+	for name, toolNames := range toolsetConfigs {
+		ts, err := tools.ToolsetConfig{Name: name, ToolNames: toolNames}.Initialize("0.0.0", toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize toolset: %s", err)
+		}
+		toolsets[name] = ts
+	}
+	authorizer, err := tools.NewAuthorizer(map[string]*tools.Policy{
+		"get_table": {AllOf: []tools.PolicyRule{{AuthService: "my-auth", Claim: "role", Equals: "admin"}}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create authorizer: %s", err)
+	}
+	admin := map[string]map[string]any{"my-auth": {"role": "admin"}}
+
+	tcs := []struct {
+		desc    string
+		tool    string
+		toolset string
+		claims  map[string]map[string]any
+		allowed bool
+	}{
+		{desc: "allowed by policy", tool: "get_table", toolset: "", claims: admin, allowed: true},
+		{desc: "denied by policy", tool: "get_table", toolset: "", claims: map[string]map[string]any{}, allowed: false},
+		{desc: "auth required without auth", tool: "get_secret", toolset: "", claims: map[string]map[string]any{}, allowed: false},
+		{desc: "auth required with auth", tool: "get_secret", toolset: "", claims: admin, allowed: true},
+		{desc: "tool outside toolset", tool: "get_secret", toolset: "tables", claims: admin, allowed: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := resources.Config{Name: "r", URI: "toolbox://r", Tool: tc.tool}.Initialize(toolsMap)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = r.Authorize(toolsets[tc.toolset], authorizer, tc.claims)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("unexpected result: want allowed %t, got error %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  resources.Config
+		err  string
+	}{
+		{
+			desc: "missing uri",
+			cfg:  resources.Config{Name: "r", Text: "t"},
+			err:  "exactly one of `uri` or `uriTemplate` must be set",
+		},
+		{
+			desc: "uri and uriTemplate",
+			cfg:  resources.Config{Name: "r", URI: "a://b", URITemplate: "a://{b}", Text: "t"},
+			err:  "exactly one of `uri` or `uriTemplate` must be set",
+		},
+		{
+			desc: "missing content",
+			cfg:  resources.Config{Name: "r", URI: "a://b"},
+			err:  "exactly one of `tool` or `text` must be set",
+		},
+		{
+			desc: "unknown tool",
+			cfg:  resources.Config{Name: "r", URI: "a://b", Tool: "foo"},
+			err:  "tool does not exist: foo",
+		},
+		{
+			desc: "template without variables",
+			cfg:  resources.Config{Name: "r", URITemplate: "a://b", Text: "t"},
+			err:  `uriTemplate "a://b" has no variables, use ` + "`uri`" + ` instead`,
+		},
+		{
+			desc: "unsupported template expression",
+			cfg:  resources.Config{Name: "r", URITemplate: "a://{b}{?c}", Text: "t"},
+			err:  `uriTemplate "a://{b}{?c}" only supports simple ` + "`{name}`" + ` expressions`,
+		},
+		{
+			desc: "template variable is not a parameter",
+			cfg:  resources.Config{Name: "r", URITemplate: "a://{foo}", Tool: "get_table"},
+			err:  `uriTemplate variable "foo" is not a parameter of tool "get_table"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(toolsMap())
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
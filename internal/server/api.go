@@ -15,6 +15,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -22,6 +23,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
@@ -82,7 +84,9 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
-	render.JSON(w, r, toolset.Manifest)
+	// only list the tools that the caller is allowed to use
+	claimsFromAuth := claimsFromRequest(ctx, s, r)
+	render.JSON(w, r, s.authorizer.ToolsetManifest(toolset, claimsFromAuth))
 }
 
 // toolGetHandler handles requests for a single Tool.
@@ -118,6 +122,11 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+	if err = s.authorizer.Authorize("", toolName, claimsFromRequest(ctx, s, r)); err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
 	// TODO: this can be optimized later with some caching
 	m := tools.ToolsetManifest{
 		ServerVersion: s.version,
@@ -166,19 +175,7 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 	// Tool authentication
 	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
-	claimsFromAuth := make(map[string]map[string]any)
-	for _, aS := range s.authServices {
-		claims, err := aS.GetClaimsFromHeader(ctx, r.Header)
-		if err != nil {
-			s.logger.DebugContext(ctx, err.Error())
-			continue
-		}
-		if claims == nil {
-			// authService not present in header
-			continue
-		}
-		claimsFromAuth[aS.GetName()] = claims
-	}
+	claimsFromAuth := claimsFromRequest(ctx, s, r)
 
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
@@ -196,6 +193,14 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
 		return
 	}
+	// Check the claims against the policies of the tool and of the toolsets
+	// that contain it
+	if err = s.authorizer.Authorize("", toolName, claimsFromAuth); err != nil {
+		err = fmt.Errorf("tool invocation not authorized: %w", err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
 
 	var data map[string]any
@@ -216,6 +221,10 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
+	// tools can use the claims and tokens of the caller, e.g. to set up the
+	// database session or to call an upstream API on its behalf
+	ctx = util.WithAuthClaims(ctx, claimsFromAuth)
+	ctx = util.WithAuthTokens(ctx, tokensFromRequest(s, r, claimsFromAuth))
 	res, err := tool.Invoke(ctx, params)
 	if err != nil {
 		err = fmt.Errorf("error while invoking tool: %w", err)
@@ -274,3 +283,48 @@ func (e *errResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	render.Status(r, e.HTTPStatusCode)
 	return nil
 }
+
+// claimsFromRequest runs every auth service on the request. It returns a map of
+// the name of each verified auth service to the claims retrieved from it.
+func claimsFromRequest(ctx context.Context, s *Server, r *http.Request) map[string]map[string]any {
+	claimsFromAuth := make(map[string]map[string]any)
+	for _, aS := range s.authServices {
+		var claims map[string]any
+		var err error
+		if rS, ok := aS.(auth.RequestAuthService); ok {
+			claims, err = rS.GetClaimsFromRequest(ctx, r)
+		} else {
+			claims, err = aS.GetClaimsFromHeader(ctx, r.Header)
+		}
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+			continue
+		}
+		if claims == nil {
+			// authService not present in request
+			continue
+		}
+		claimsFromAuth[aS.GetName()] = claims
+	}
+	return claimsFromAuth
+}
+
+// tokensFromRequest returns a map of the name of each verified auth service to
+// the raw token sent for it, so that tools can forward the token upstream.
+// Only auth services that verify bearer tokens are included.
+func tokensFromRequest(s *Server, r *http.Request, claimsFromAuth map[string]map[string]any) map[string]string {
+	tokens := make(map[string]string)
+	for _, aS := range s.authServices {
+		tS, ok := aS.(auth.TokenAuthService)
+		if !ok {
+			continue
+		}
+		if _, verified := claimsFromAuth[aS.GetName()]; !verified {
+			continue
+		}
+		if token := tS.GetTokenFromHeader(r.Header); token != "" {
+			tokens[aS.GetName()] = token
+		}
+	}
+	return tokens
+}
@@ -20,9 +20,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
 
@@ -281,3 +284,137 @@ func TestToolInvokeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestToolPolicy(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	authServices := map[string]auth.AuthService{"my-auth": MockAuthService{Name: "my-auth"}}
+	policy := &tools.Policy{AllOf: []tools.PolicyRule{{Claim: "sub", Equals: "alice"}}}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets,
+		withAuthServices(authServices),
+		withAuthorizer(t, map[string]*tools.Policy{tool2.Name: policy}, nil),
+	)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name      string
+		header    map[string]string
+		wantTools []string
+		wantCode  int
+	}{
+		{
+			name:      "no claims",
+			wantTools: []string{tool1.Name},
+			wantCode:  http.StatusForbidden,
+		},
+		{
+			name:      "claims not allowed by the policy",
+			header:    map[string]string{"my-auth_token": "bob"},
+			wantTools: []string{tool1.Name},
+			wantCode:  http.StatusForbidden,
+		},
+		{
+			name:      "claims allowed by the policy",
+			header:    map[string]string{"my-auth_token": "alice"},
+			wantTools: []string{tool1.Name, tool2.Name},
+			wantCode:  http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodGet, "/toolset/", nil, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+			var m tools.ToolsetManifest
+			if err := json.Unmarshal(body, &m); err != nil {
+				t.Fatalf("unable to parse ToolsetManifest: %s", err)
+			}
+			var gotTools []string
+			for name := range m.ToolsManifest {
+				gotTools = append(gotTools, name)
+			}
+			sort.Strings(gotTools)
+			if !reflect.DeepEqual(gotTools, tc.wantTools) {
+				t.Fatalf("unexpected tools: got %v, want %v", gotTools, tc.wantTools)
+			}
+
+			reqBody := bytes.NewBufferString(`{"param1": 1, "param2": 2}`)
+			resp, _, err = runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool2.Name), reqBody, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantCode {
+				t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestToolsetPolicy(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	authServices := map[string]auth.AuthService{"my-auth": MockAuthService{Name: "my-auth"}}
+	policy := &tools.Policy{AllOf: []tools.PolicyRule{{Claim: "sub", Equals: "alice"}}}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets,
+		withAuthServices(authServices),
+		withAuthorizer(t, nil, map[string]*tools.Policy{"tool1_only": policy}),
+	)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// the policy of a toolset applies to its tools outside of the toolset
+	testCases := []struct {
+		name     string
+		header   map[string]string
+		wantCode int
+	}{
+		{
+			name:     "no claims",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "claims not allowed by the policy",
+			header:   map[string]string{"my-auth_token": "bob"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "claims allowed by the policy",
+			header:   map[string]string{"my-auth_token": "alice"},
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, _, err := runRequest(ts, http.MethodGet, fmt.Sprintf("/tool/%s", tool1.Name), nil, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantCode {
+				t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, tc.wantCode)
+			}
+
+			resp, _, err = runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool1.Name), bytes.NewBufferString(`{}`), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantCode {
+				t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, tc.wantCode)
+			}
+		})
+	}
+
+	// tools outside of the toolset are not restricted
+	resp, _, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool2.Name), bytes.NewBufferString(`{"param1": 1, "param2": 2}`), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
@@ -22,9 +22,13 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
@@ -86,6 +90,51 @@ func (t MockTool) McpManifest() tools.McpManifest {
 	}
 }
 
+var _ auth.AuthService = MockAuthService{}
+
+// MockAuthService is used to mock auth services in tests. It accepts any
+// `<name>_token` header, and returns the token as the `sub` claim.
+type MockAuthService struct {
+	Name string
+}
+
+func (a MockAuthService) AuthServiceKind() string {
+	return "mock"
+}
+
+func (a MockAuthService) GetName() string {
+	return a.Name
+}
+
+func (a MockAuthService) GetClaimsFromHeader(_ context.Context, h http.Header) (map[string]any, error) {
+	token := h.Get(a.Name + "_token")
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]any{"sub": token}, nil
+}
+
+var _ auth.TokenAuthService = MockTokenAuthService{}
+
+// MockTokenAuthService is used to mock auth services that verify bearer
+// tokens in tests. It accepts any `<name>_token` header, and returns the token
+// as the `sub` claim with an `exp` claim an hour from now.
+type MockTokenAuthService struct {
+	MockAuthService
+}
+
+func (a MockTokenAuthService) GetClaimsFromHeader(_ context.Context, h http.Header) (map[string]any, error) {
+	token := a.GetTokenFromHeader(h)
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]any{"sub": token, "exp": float64(time.Now().Add(time.Hour).Unix())}, nil
+}
+
+func (a MockTokenAuthService) GetTokenFromHeader(h http.Header) string {
+	return h.Get(a.Name + "_token")
+}
+
 var tool1 = MockTool{
 	Name:   "no_params",
 	Params: []tools.Parameter{},
@@ -133,8 +182,68 @@ func setUpResources(t *testing.T, mockTools []MockTool) (map[string]tools.Tool,
 	return toolsMap, toolsets
 }
 
+// serverOption configures additional fields of the server used in tests
+type serverOption func(*Server)
+
+// withResources sets the MCP resources served by the test server
+func withResources(resourcesMap map[string]resources.Resource) serverOption {
+	return func(s *Server) {
+		s.resources = resourcesMap
+	}
+}
+
+// withPrompts sets the MCP prompts served by the test server
+func withPrompts(promptsMap map[string]prompts.Prompt) serverOption {
+	return func(s *Server) {
+		s.prompts = promptsMap
+	}
+}
+
+// withAuthServices sets the auth services used by the test server
+func withAuthServices(authServices map[string]auth.AuthService) serverOption {
+	return func(s *Server) {
+		s.authServices = authServices
+	}
+}
+
+// withProtectedResource protects the MCP endpoint of the test server with an
+// OAuth 2.0 protected resource auth service
+func withProtectedResource(pr auth.ProtectedResource) serverOption {
+	return func(s *Server) {
+		s.protectedResource = pr
+		s.authServices = map[string]auth.AuthService{pr.GetName(): pr}
+	}
+}
+
+// withAuthorizer sets the authorizer that evaluates the policies of tools and
+// toolsets in the test server
+func withAuthorizer(t *testing.T, toolPolicies, toolsetPolicies map[string]*tools.Policy) serverOption {
+	return func(s *Server) {
+		toolsetConfigs := make(map[string]tools.ToolsetConfig)
+		for name, ts := range s.toolsets {
+			cfg := tools.ToolsetConfig{Name: name, Policy: toolsetPolicies[name]}
+			for toolName := range ts.Manifest.ToolsManifest {
+				cfg.ToolNames = append(cfg.ToolNames, toolName)
+			}
+			toolsetConfigs[name] = cfg
+		}
+		a, err := tools.NewAuthorizer(toolPolicies, toolsetConfigs)
+		if err != nil {
+			t.Fatalf("unable to create authorizer: %s", err)
+		}
+		s.authorizer = a
+	}
+}
+
+// withMcpPageSize sets the number of tools per MCP tools/list page
+func withMcpPageSize(pageSize int) serverOption {
+	return func(s *Server) {
+		s.mcpPageSize = pageSize
+	}
+}
+
 // setUpServer create a new server with tools and toolsets that are given
-func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset) (chi.Router, func()) {
+func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, toolsets map[string]tools.Toolset, opts ...serverOption) (chi.Router, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
@@ -153,8 +262,13 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 	}
 
 	sseManager := newSseManager(ctx)
+	streamManager := newStreamManager(ctx)
+	sessionManager := newSessionManager(ctx, 0)
 
-	server := Server{version: fakeVersionString, logger: testLogger, instrumentation: instrumentation, sseManager: sseManager, tools: tools, toolsets: toolsets}
+	server := Server{version: fakeVersionString, logger: testLogger, instrumentation: instrumentation, sseManager: sseManager, streamManager: streamManager, sessionManager: sessionManager, tools: tools, toolsets: toolsets}
+	for _, o := range opts {
+		o(&server)
+	}
 	var r chi.Router
 	switch router {
 	case "api":
@@ -17,10 +17,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/auth/hmac"
+	"github.com/googleapis/genai-toolbox/internal/auth/oauthresource"
+	"github.com/googleapis/genai-toolbox/internal/auth/oidc"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
@@ -41,6 +48,10 @@ type ServerConfig struct {
 	ToolConfigs ToolConfigs
 	// ToolsetConfigs defines what tools are available.
 	ToolsetConfigs ToolsetConfigs
+	// ResourceConfigs defines what MCP resources are available.
+	ResourceConfigs ResourceConfigs
+	// PromptConfigs defines what MCP prompts are available.
+	PromptConfigs PromptConfigs
 	// LoggingFormat defines whether structured loggings are used.
 	LoggingFormat logFormat
 	// LogLevel defines the levels to log.
@@ -53,6 +64,11 @@ type ServerConfig struct {
 	TelemetryServiceName string
 	// Stdio indicates if Toolbox is listening via MCP stdio.
 	Stdio bool
+	// McpPageSize is the maximum number of tools returned per MCP `tools/list`
+	// page. A value of 0 returns all tools in a single page.
+	McpPageSize int
+	// McpSessionTTL is how long an idle streamable HTTP session is kept.
+	McpSessionTTL time.Duration
 }
 
 type logFormat string
@@ -187,6 +203,30 @@ func (c *AuthServiceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(i
 				return fmt.Errorf("unable to parse as %q: %w", kind, err)
 			}
 			(*c)[name] = actual
+		case oidc.AuthServiceKind:
+			actual := oidc.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
+		case oauthresource.AuthServiceKind:
+			actual := oauthresource.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
+		case apikey.AuthServiceKind:
+			actual := apikey.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
+		case hmac.AuthServiceKind:
+			actual := hmac.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
 		default:
 			return fmt.Errorf("%q is not a valid kind of auth source", kind)
 		}
@@ -251,13 +291,99 @@ var _ yaml.InterfaceUnmarshalerContext = &ToolsetConfigs{}
 func (c *ToolsetConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
 	*c = make(ToolsetConfigs)
 
-	var raw map[string][]string
+	var raw map[string]util.DelayedUnmarshaler
 	if err := unmarshal(&raw); err != nil {
 		return err
 	}
 
-	for name, toolList := range raw {
-		(*c)[name] = tools.ToolsetConfig{Name: name, ToolNames: toolList}
+	for name, u := range raw {
+		// a toolset is either a list of tool names, or an object with the
+		// tool names and a policy
+		var toolList []string
+		if err := u.Unmarshal(&toolList); err == nil {
+			(*c)[name] = tools.ToolsetConfig{Name: name, ToolNames: toolList}
+			continue
+		}
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			return fmt.Errorf("unable to unmarshal toolset %q: must be a list of tool names or an object", name)
+		}
+		dec, err := util.NewStrictDecoder(v)
+		if err != nil {
+			return fmt.Errorf("error creating YAML decoder for toolset %q: %w", name, err)
+		}
+		var actual struct {
+			Tools  []string      `yaml:"tools" validate:"required"`
+			Policy *tools.Policy `yaml:"policy"`
+		}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return fmt.Errorf("unable to parse toolset %q: %w", name, err)
+		}
+		(*c)[name] = tools.ToolsetConfig{Name: name, ToolNames: actual.Tools, Policy: actual.Policy}
+	}
+	return nil
+}
+
+// ResourceConfigs is a type used to allow unmarshal of the resource configs
+type ResourceConfigs map[string]resources.Config
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &ResourceConfigs{}
+
+func (c *ResourceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(ResourceConfigs)
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	for name, u := range raw {
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
+		}
+
+		dec, err := util.NewStrictDecoder(v)
+		if err != nil {
+			return fmt.Errorf("error creating YAML decoder for resource %q: %w", name, err)
+		}
+		actual := resources.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return fmt.Errorf("unable to parse resource %q: %w", name, err)
+		}
+		(*c)[name] = actual
+	}
+	return nil
+}
+
+// PromptConfigs is a type used to allow unmarshal of the prompt configs
+type PromptConfigs map[string]prompts.Config
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &PromptConfigs{}
+
+func (c *PromptConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(PromptConfigs)
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	for name, u := range raw {
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
+		}
+
+		dec, err := util.NewStrictDecoder(v)
+		if err != nil {
+			return fmt.Errorf("error creating YAML decoder for prompt %q: %w", name, err)
+		}
+		actual := prompts.Config{Name: name}
+		if err := dec.DecodeContext(ctx, &actual); err != nil {
+			return fmt.Errorf("unable to parse prompt %q: %w", name, err)
+		}
+		(*c)[name] = actual
 	}
 	return nil
 }
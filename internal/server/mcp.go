@@ -19,9 +19,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,11 +32,13 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
 	v20250326 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250326"
+	v20250618 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250618"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -46,6 +51,11 @@ type sseSession struct {
 	done       chan struct{}
 	eventQueue chan string
 	lastActive time.Time
+	// claims and tokens of the bearer tokens verified from the request that
+	// opened the stream, and when they expire
+	claims   map[string]map[string]any
+	tokens   map[string]string
+	expiries map[string]time.Time
 }
 
 // sseManager manages and control access to sse sessions
@@ -108,15 +118,22 @@ func (m *sseManager) cleanupRoutine(ctx context.Context) {
 	}
 }
 
+// stdioMaxConcurrentRequests is the number of requests from a stdio client
+// that are processed at the same time.
+const stdioMaxConcurrentRequests = 10
+
 type stdioSession struct {
+	id       string
 	protocol string
 	server   *Server
 	reader   *bufio.Reader
 	writer   io.Writer
+	writeMu  sync.Mutex
 }
 
 func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession {
 	stdioSession := &stdioSession{
+		id:     uuid.New().String(),
 		server: s,
 		reader: bufio.NewReader(stdin),
 		writer: stdout,
@@ -128,35 +145,83 @@ func (s *stdioSession) Start(ctx context.Context) error {
 	return s.readInputStream(ctx)
 }
 
-// readInputStream reads requests/notifications from MCP clients through stdin
+// readInputStream reads requests/notifications from MCP clients through stdin.
+// Requests are processed concurrently by a bounded pool of workers, so that a
+// long running tool call doesn't block the following messages.
 func (s *stdioSession) readInputStream(ctx context.Context) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	// in-flight requests still get their response when stdin is closed
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	workers := make(chan struct{}, stdioMaxConcurrentRequests)
 	for {
 		if err := ctx.Err(); err != nil {
-			return err
+			return context.Cause(ctx)
 		}
 		line, err := s.readLine(ctx)
 		if err != nil {
 			if err == io.EOF {
 				return nil
 			}
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
 			return err
 		}
-		v, res, err := processMcpMessage(ctx, []byte(line), s.server, s.protocol, "")
-		if err != nil {
-			// errors during the processing of message will generate a valid MCP Error response.
-			// server can continue to run.
-			s.server.logger.ErrorContext(ctx, err.Error())
+
+		// notifications and the initialize request are processed right away,
+		// so that cancellations are not blocked by running requests and the
+		// negotiated protocol version is set before the following requests.
+		if !hasRequests([]byte(line)) {
+			v, err := s.processMessage(ctx, line, s.protocol)
+			if v != "" {
+				s.protocol = v
+			}
+			if err != nil {
+				return err
+			}
+			continue
 		}
-		if v != "" {
-			s.protocol = v
+
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			return context.Cause(ctx)
 		}
-		// no responses for notifications
-		if res != nil {
-			if err = s.write(ctx, res); err != nil {
-				return err
+		wg.Add(1)
+		go func(protocol string) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			if _, err := s.processMessage(ctx, line, protocol); err != nil {
+				cancel(err)
 			}
+		}(s.protocol)
+	}
+}
+
+// processMessage processes a message from stdin and writes its response to
+// stdout. It returns the protocol version negotiated by an initialize request.
+func (s *stdioSession) processMessage(ctx context.Context, line, protocol string) (string, error) {
+	msgCtx := mcputil.WithNotificationSender(ctx, func(notification any) {
+		if err := s.write(ctx, notification); err != nil {
+			s.server.logger.DebugContext(ctx, fmt.Sprintf("unable to send notification: %s", err))
+		}
+	})
+	v, res, err := processMcpMessage(msgCtx, []byte(line), s.server, s.id, protocol, "")
+	if err != nil {
+		// errors during the processing of message will generate a valid MCP Error response.
+		// server can continue to run.
+		s.server.logger.ErrorContext(ctx, err.Error())
+	}
+	// no responses for notifications
+	if res != nil {
+		if err = s.write(ctx, res); err != nil {
+			return v, err
 		}
 	}
+	return v, nil
 }
 
 // readLine process each line within the input stream.
@@ -206,6 +271,10 @@ func (s *stdioSession) readLine(ctx context.Context) (string, error) {
 func (s *stdioSession) write(ctx context.Context, response any) error {
 	res, _ := json.Marshal(response)
 
+	// responses and notifications of concurrent requests are written one at a
+	// time, so that their lines are not interleaved
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	_, err := fmt.Fprintf(s.writer, "%s\n", res)
 	return err
 }
@@ -217,17 +286,20 @@ func mcpRouter(s *Server) (chi.Router, error) {
 	r.Use(middleware.AllowContentType("application/json"))
 	r.Use(middleware.StripSlashes)
 	r.Use(render.SetContentType(render.ContentTypeJSON))
+	if s.protectedResource != nil {
+		r.Use(protectedResourceMiddleware(s))
+	}
 
 	r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-	r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) { streamHandler(s, w, r) })
 	r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-	r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+	r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
 
 	r.Route("/{toolsetName}", func(r chi.Router) {
 		r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { streamHandler(s, w, r) })
 		r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
 	})
 
 	return r, nil
@@ -280,6 +352,9 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		done:       make(chan struct{}),
 		eventQueue: make(chan string, 100),
 	}
+	claims := claimsFromRequest(ctx, s, r)
+	session.claims, session.tokens = sessionClaims(s, claims, tokensFromRequest(s, r, claims))
+	session.expiries = claimsExpiries(session.claims)
 	s.sseManager.add(sessionId, session)
 	defer s.sseManager.remove(sessionId)
 
@@ -320,11 +395,252 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// methodNotAllowed handles all mcp messages.
-func methodNotAllowed(s *Server, w http.ResponseWriter, r *http.Request) {
-	err := fmt.Errorf("toolbox does not support streaming in streamable HTTP transport")
-	s.logger.DebugContext(r.Context(), err.Error())
-	_ = render.Render(w, r, newErrResponse(err, http.StatusMethodNotAllowed))
+// acceptsEventStream returns true if the client accepts SSE responses.
+func acceptsEventStream(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for _, t := range strings.Split(v, ",") {
+			mediaType, _, _ := strings.Cut(strings.TrimSpace(t), ";")
+			if mediaType == "text/event-stream" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setEventStreamHeaders sets the headers of an SSE response.
+func setEventStreamHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+}
+
+// streamHandler resumes a previous stream of the session with the
+// `Last-Event-ID` header. Since the server doesn't send messages outside of a
+// request, standalone streams aren't supported.
+func streamHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/mcp/stream")
+	r = r.WithContext(ctx)
+
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if !acceptsEventStream(r) {
+		err = fmt.Errorf("streamable HTTP GET requests must accept text/event-stream")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusMethodNotAllowed))
+		return
+	}
+
+	sessionId := r.Header.Get("Mcp-Session-Id")
+	if sessionId == "" {
+		err = fmt.Errorf("missing Mcp-Session-Id header")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	if _, ok := s.sessionManager.get(sessionId, chi.URLParam(r, "toolsetName")); !ok {
+		err = fmt.Errorf("session not found: %s", sessionId)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		err = fmt.Errorf("standalone streams are not supported, set Last-Event-ID to resume a stream")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusMethodNotAllowed))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err = fmt.Errorf("unable to retrieve flusher for stream")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	stream, seq, err := s.streamManager.resume(lastEventId, sessionId)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	s.logger.DebugContext(ctx, fmt.Sprintf("resuming stream %s after event %d", stream.id, seq))
+	span.SetAttributes(attribute.String("stream_id", stream.id))
+
+	setEventStreamHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	if err = stream.write(ctx, w, flusher, seq); err != nil {
+		s.logger.DebugContext(ctx, fmt.Sprintf("stream %s disconnected: %s", stream.id, err))
+		// a client disconnection is expected while resuming a stream
+		err = nil
+	}
+}
+
+// deleteSessionHandler ends the streamable HTTP session of the client.
+func deleteSessionHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	sessionId := r.Header.Get("Mcp-Session-Id")
+	if sessionId == "" {
+		err := fmt.Errorf("missing Mcp-Session-Id header")
+		s.logger.DebugContext(r.Context(), err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+	if !s.sessionManager.remove(sessionId, chi.URLParam(r, "toolsetName")) {
+		err := fmt.Errorf("session not found: %s", sessionId)
+		s.logger.DebugContext(r.Context(), err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	s.logger.DebugContext(r.Context(), fmt.Sprintf("session %s ended", sessionId))
+	w.WriteHeader(http.StatusOK)
+}
+
+// streamResponse answers a POST request with an SSE stream. The messages are
+// processed in the background, so that the stream can be resumed if the client
+// disconnects before the response is ready.
+func streamResponse(ctx context.Context, s *Server, w http.ResponseWriter, flusher http.Flusher, body []byte, sessionId, protocolVersion, toolsetName string) error {
+	stream := s.streamManager.newStream(sessionId)
+	s.logger.DebugContext(ctx, fmt.Sprintf("responding on stream %s", stream.id))
+
+	go func() {
+		defer stream.close()
+		msgCtx := mcputil.WithNotificationSender(context.WithoutCancel(ctx), func(notification any) {
+			data, err := json.Marshal(notification)
+			if err != nil {
+				s.logger.DebugContext(ctx, fmt.Sprintf("unable to marshal notification: %s", err))
+				return
+			}
+			stream.send(data)
+		})
+		_, res, err := processMcpMessage(msgCtx, body, s, sessionId, protocolVersion, toolsetName)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+		}
+		if res == nil {
+			return
+		}
+		data, err := json.Marshal(res)
+		if err != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("unable to marshal response: %s", err))
+			return
+		}
+		stream.send(data)
+	}()
+
+	setEventStreamHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	return stream.write(ctx, w, flusher, 0)
+}
+
+// hasRequests returns true if the body contains at least one request other
+// than initialize, which are the messages that can be answered with a stream.
+func hasRequests(body []byte) bool {
+	var messages []jsonrpc.BaseMessage
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return false
+		}
+	} else {
+		var m jsonrpc.BaseMessage
+		if err := json.Unmarshal(body, &m); err != nil {
+			return false
+		}
+		messages = append(messages, m)
+	}
+	for _, m := range messages {
+		if m.Id != nil && m.Method != mcputil.INITIALIZE {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeClaims returns the claims, or tokens, of a session, updated with the
+// ones of a request. The claims and tokens of the session without an expiry,
+// or that have expired, are dropped.
+func mergeClaims[T any](sessionClaims map[string]T, expiries map[string]time.Time, requestClaims map[string]T) map[string]T {
+	now := time.Now()
+	claims := make(map[string]T, len(sessionClaims)+len(requestClaims))
+	for name, c := range sessionClaims {
+		if exp, ok := expiries[name]; !ok || !now.Before(exp) {
+			continue
+		}
+		claims[name] = c
+	}
+	for name, c := range requestClaims {
+		claims[name] = c
+	}
+	return claims
+}
+
+// sessionClaims returns the claims and tokens verified when a session starts
+// that are kept for its later requests. Only the claims of bearer tokens with
+// an `exp` claim are kept. Auth services that verify each request, such as api
+// keys and signatures, must authenticate every request of the session.
+func sessionClaims(s *Server, claimsFromAuth map[string]map[string]any, tokens map[string]string) (map[string]map[string]any, map[string]string) {
+	keptClaims := make(map[string]map[string]any)
+	keptTokens := make(map[string]string)
+	for name := range claimsExpiries(claimsFromAuth) {
+		if _, ok := s.authServices[name].(auth.TokenAuthService); !ok {
+			continue
+		}
+		keptClaims[name] = claimsFromAuth[name]
+		if token, ok := tokens[name]; ok {
+			keptTokens[name] = token
+		}
+	}
+	return keptClaims, keptTokens
+}
+
+// claimsExpiries returns when the claims of each auth service expire, from
+// their `exp` claim. Claims without an `exp` claim are left out.
+func claimsExpiries(claimsFromAuth map[string]map[string]any) map[string]time.Time {
+	expiries := make(map[string]time.Time)
+	for name, claims := range claimsFromAuth {
+		var exp int64
+		switch v := claims["exp"].(type) {
+		case float64:
+			exp = int64(v)
+		case int64:
+			exp = v
+		case int:
+			exp = int64(v)
+		case json.Number:
+			n, err := v.Int64()
+			if err != nil {
+				continue
+			}
+			exp = n
+		default:
+			continue
+		}
+		expiries[name] = time.Unix(exp, 0)
+	}
+	return expiries
+}
+
+// startsSession returns true if clients of protocolVersion use a streamable
+// HTTP session.
+func startsSession(protocolVersion string) bool {
+	return protocolVersion == v20250326.PROTOCOL_VERSION || protocolVersion == v20250618.PROTOCOL_VERSION
+}
+
+// isInitializeRequest returns true if body is an initialize request.
+func isInitializeRequest(body []byte) bool {
+	var m jsonrpc.BaseMessage
+	if err := json.Unmarshal(body, &m); err != nil {
+		return false
+	}
+	return m.Method == mcputil.INITIALIZE
 }
 
 // httpHandler handles all mcp messages.
@@ -350,12 +666,9 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check if client have `Mcp-Session-Id` header
-	// if `Mcp-Session-Id` header is set, we are using v2025-03-26 since
-	// previous version doesn't use this header.
+	// if `Mcp-Session-Id` header is set, the client is using the streamable
+	// HTTP transport and the protocol version negotiated for the session.
 	headerSessionId := r.Header.Get("Mcp-Session-Id")
-	if headerSessionId != "" {
-		protocolVersion = v20250326.PROTOCOL_VERSION
-	}
 
 	toolsetName := chi.URLParam(r, "toolsetName")
 	s.logger.DebugContext(ctx, fmt.Sprintf("toolset name: %s", toolsetName))
@@ -380,6 +693,47 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		)
 	}()
 
+	var mcpSession *mcpSession
+	if headerSessionId != "" {
+		var ok bool
+		mcpSession, ok = s.sessionManager.get(headerSessionId, toolsetName)
+		if !ok {
+			err = fmt.Errorf("session not found: %s", headerSessionId)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+			return
+		}
+		sessionId = mcpSession.id
+		protocolVersion = mcpSession.protocolVersion
+	}
+
+	// since v2025-06-18, clients send the negotiated protocol version in the
+	// `MCP-Protocol-Version` header.
+	if headerVersion := r.Header.Get("MCP-Protocol-Version"); headerVersion != "" {
+		if !slices.Contains(mcp.SUPPORTED_PROTOCOL_VERSIONS, headerVersion) || (mcpSession != nil && headerVersion != mcpSession.protocolVersion) {
+			err = fmt.Errorf("invalid MCP-Protocol-Version header: %s", headerVersion)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
+		}
+		protocolVersion = headerVersion
+	}
+
+	// claims from the request take precedence over the claims
+	// verified when the session started
+	claims := claimsFromRequest(ctx, s, r)
+	tokens := tokensFromRequest(s, r, claims)
+	switch {
+	case session != nil:
+		claims = mergeClaims(session.claims, session.expiries, claims)
+		tokens = mergeClaims(session.tokens, session.expiries, tokens)
+	case mcpSession != nil:
+		claims = mergeClaims(mcpSession.claims, mcpSession.expiries, claims)
+		tokens = mergeClaims(mcpSession.tokens, mcpSession.expiries, tokens)
+	}
+	ctx = util.WithAuthClaims(ctx, claims)
+	ctx = util.WithAuthTokens(ctx, tokens)
+
 	// Read and returns a body from io.Reader
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -389,7 +743,41 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		render.JSON(w, r, jsonrpc.NewError(id, jsonrpc.PARSE_ERROR, err.Error(), nil))
 	}
 
-	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName)
+	// since v2025-03-26, every request after initialization must be sent in
+	// the session that was started.
+	if session == nil && mcpSession == nil && startsSession(protocolVersion) && !isInitializeRequest(body) {
+		err = fmt.Errorf("missing Mcp-Session-Id header")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+		return
+	}
+
+	// streamable HTTP clients that accept SSE get their responses on a stream
+	if mcpSession != nil && acceptsEventStream(r) && hasRequests(body) {
+		if flusher, ok := w.(http.Flusher); ok {
+			if err = streamResponse(ctx, s, w, flusher, body, sessionId, protocolVersion, toolsetName); err != nil {
+				s.logger.DebugContext(ctx, fmt.Sprintf("client disconnected from stream: %s", err))
+			}
+			return
+		}
+	}
+
+	// notifications can only be sent to clients connected via sse
+	if session != nil {
+		ctx = mcputil.WithNotificationSender(ctx, func(notification any) {
+			queueSseEvent(ctx, s, session, notification)
+		})
+	}
+
+	// requests are tracked per session, so that clients can cancel them.
+	// Requests without a session are tracked per HTTP request, so that other
+	// clients can't cancel them.
+	trackingId := sessionId
+	if session == nil && mcpSession == nil {
+		trackingId = uuid.New().String()
+	}
+
+	v, res, err := processMcpMessage(ctx, body, s, trackingId, protocolVersion, toolsetName)
 	// notifications will return empty string
 	if res == nil {
 		// Notifications do not expect a response
@@ -401,31 +789,115 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		s.logger.DebugContext(ctx, err.Error())
 	}
 
-	// for v20250326, add the `Mcp-Session-Id` header
-	if v == v20250326.PROTOCOL_VERSION {
-		sessionId = uuid.New().String()
+	// since v20250326, start a session and add the `Mcp-Session-Id` header
+	if startsSession(v) {
+		var req mcputil.InitializeRequest
+		if unmarshalErr := json.Unmarshal(body, &req); unmarshalErr != nil {
+			s.logger.DebugContext(ctx, fmt.Sprintf("unable to read client capabilities: %s", unmarshalErr))
+		}
+		sClaims, sTokens := sessionClaims(s, claims, tokens)
+		mcpSession := s.sessionManager.create(v, toolsetName, req.Params.Capabilities, sClaims, sTokens)
+		sessionId = mcpSession.id
 		w.Header().Set("Mcp-Session-Id", sessionId)
 	}
 
 	if session != nil {
-		// queue sse event
-		eventData, _ := json.Marshal(res)
-		select {
-		case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
-			s.logger.DebugContext(ctx, "event queue successful")
-		case <-session.done:
-			s.logger.DebugContext(ctx, "session is close")
-		default:
-			s.logger.DebugContext(ctx, "unable to add to event queue")
-		}
+		queueSseEvent(ctx, s, session, res)
 	}
 
 	// send HTTP response
 	render.JSON(w, r, res)
 }
 
-// processMcpMessage process the messages received from clients
-func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string) (string, any, error) {
+// queueSseEvent queues a message to be sent on a sse session.
+func queueSseEvent(ctx context.Context, s *Server, session *sseSession, message any) {
+	eventData, _ := json.Marshal(message)
+	select {
+	case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
+		s.logger.DebugContext(ctx, "event queue successful")
+	case <-session.done:
+		s.logger.DebugContext(ctx, "session is close")
+	default:
+		s.logger.DebugContext(ctx, "unable to add to event queue")
+	}
+}
+
+// processMcpMessage process the messages received from clients. A JSON-RPC
+// batch is answered with an array of responses, or with no response at all if
+// it only contains notifications.
+func processMcpMessage(ctx context.Context, body []byte, s *Server, sessionId, protocolVersion, toolsetName string) (string, any, error) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		return processMcpBatch(ctx, trimmed, s, sessionId, protocolVersion, toolsetName)
+	}
+	return processMcpRequest(ctx, body, s, sessionId, protocolVersion, toolsetName)
+}
+
+// processMcpBatch processes each message of a batch concurrently. Responses
+// are returned in the same order as their requests.
+func processMcpBatch(ctx context.Context, body []byte, s *Server, sessionId, protocolVersion, toolsetName string) (string, any, error) {
+	if !mcp.SupportsBatch(protocolVersion) {
+		id := uuid.New().String()
+		err := fmt.Errorf("batch requests are not supported in protocol version %s", protocolVersion)
+		return "", jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	var messages []json.RawMessage
+	if err := json.Unmarshal(body, &messages); err != nil {
+		// Generate a new uuid if unable to decode
+		id := uuid.New().String()
+		return "", jsonrpc.NewError(id, jsonrpc.PARSE_ERROR, err.Error(), nil), err
+	}
+	if len(messages) == 0 {
+		id := uuid.New().String()
+		err := fmt.Errorf("batch must contain at least one message")
+		return "", jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	results := make([]any, len(messages))
+	errs := make([]error, len(messages))
+	var wg sync.WaitGroup
+	for i, message := range messages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = processBatchedMessage(ctx, message, s, sessionId, protocolVersion, toolsetName)
+		}()
+	}
+	wg.Wait()
+
+	// notifications do not have a response
+	responses := make([]any, 0, len(results))
+	for _, res := range results {
+		if res != nil {
+			responses = append(responses, res)
+		}
+	}
+	if len(responses) == 0 {
+		return "", nil, errors.Join(errs...)
+	}
+	return "", responses, errors.Join(errs...)
+}
+
+// processBatchedMessage processes a single message of a batch.
+func processBatchedMessage(ctx context.Context, body []byte, s *Server, sessionId, protocolVersion, toolsetName string) (any, error) {
+	var baseMessage jsonrpc.BaseMessage
+	if err := util.DecodeJSON(bytes.NewBuffer(body), &baseMessage); err != nil {
+		err = fmt.Errorf("invalid message in batch: %w", err)
+		return jsonrpc.NewError(nil, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	// the initialize request must not be part of a batch, since its response
+	// determines how the rest of the messages are processed.
+	if baseMessage.Method == mcputil.INITIALIZE {
+		err := fmt.Errorf("initialize request must not be part of a batch")
+		return jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+	_, res, err := processMcpRequest(ctx, body, s, sessionId, protocolVersion, toolsetName)
+	return res, err
+}
+
+// processMcpRequest process a single request or notification received from
+// clients.
+func processMcpRequest(ctx context.Context, body []byte, s *Server, sessionId, protocolVersion, toolsetName string) (string, any, error) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		return "", jsonrpc.NewError("", jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
@@ -440,15 +912,6 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 	if err = util.DecodeJSON(bytes.NewBuffer(body), &baseMessage); err != nil {
 		// Generate a new uuid if unable to decode
 		id := uuid.New().String()
-
-		// check if user is sending a batch request
-		var a []any
-		unmarshalErr := json.Unmarshal(body, &a)
-		if unmarshalErr == nil {
-			err = fmt.Errorf("not supporting batch requests")
-			return "", jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
-		}
-
 		return "", jsonrpc.NewError(id, jsonrpc.PARSE_ERROR, err.Error(), nil), err
 	}
 
@@ -467,8 +930,19 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 
 	// Check if message is a notification
 	if baseMessage.Id == nil {
-		err := mcp.NotificationHandler(ctx, body)
-		return "", nil, err
+		if err := mcp.NotificationHandler(ctx, body); err != nil {
+			return "", nil, err
+		}
+		if baseMessage.Method == mcputil.NOTIFICATIONS_CANCELLED {
+			var notification mcputil.CancelledNotification
+			if err := util.DecodeJSON(bytes.NewBuffer(body), &notification); err != nil {
+				return "", nil, fmt.Errorf("invalid cancelled notification: %w", err)
+			}
+			if s.requests.cancel(sessionId, notification.Params.RequestId) {
+				logger.DebugContext(ctx, fmt.Sprintf("cancelled request %v: %s", notification.Params.RequestId, notification.Params.Reason))
+			}
+		}
+		return "", nil, nil
 	}
 
 	switch baseMessage.Method {
@@ -484,7 +958,14 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 			err = fmt.Errorf("toolset does not exist")
 			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 		}
-		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, s.tools, body)
+		// track the request, so that the client can cancel it
+		ctx, done := s.requests.track(ctx, sessionId, baseMessage.Id)
+		defer done()
+		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, s.authorizer, s.mcpPageSize, s.tools, s.resources, s.prompts, body)
+		// the client does not expect a response for a cancelled request
+		if errors.Is(context.Cause(ctx), errRequestCancelled) {
+			return "", nil, err
+		}
 		return "", res, err
 	}
 }
@@ -20,19 +20,22 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
 	v20250326 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250326"
+	v20250618 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250618"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
 
 // LATEST_PROTOCOL_VERSION is the latest version of the MCP protocol supported.
 // Update the version used in InitializeResponse when this value is updated.
-const LATEST_PROTOCOL_VERSION = v20250326.PROTOCOL_VERSION
+const LATEST_PROTOCOL_VERSION = v20250618.PROTOCOL_VERSION
 
 // SUPPORTED_PROTOCOL_VERSIONS is the MCP protocol versions that are supported.
-var SUPPORTED_PROTOCOL_VERSIONS = []string{v20241105.PROTOCOL_VERSION, v20250326.PROTOCOL_VERSION}
+var SUPPORTED_PROTOCOL_VERSIONS = []string{v20241105.PROTOCOL_VERSION, v20250326.PROTOCOL_VERSION, v20250618.PROTOCOL_VERSION}
 
 // InitializeResponse runs capability negotiation and protocol version agreement.
 // This is the Initialization phase of the lifecycle for MCP client-server connections.
@@ -53,9 +56,17 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 	}
 
 	toolsListChanged := false
+	resourcesListChanged := false
+	promptsListChanged := false
 	result := mcputil.InitializeResult{
 		ProtocolVersion: protocolVersion,
 		Capabilities: mcputil.ServerCapabilities{
+			Prompts: &mcputil.ListChanged{
+				ListChanged: &promptsListChanged,
+			},
+			Resources: &mcputil.ListChanged{
+				ListChanged: &resourcesListChanged,
+			},
 			Tools: &mcputil.ListChanged{
 				ListChanged: &toolsListChanged,
 			},
@@ -74,8 +85,25 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 	return res, protocolVersion, nil
 }
 
+// PingResponse returns the empty result that answers a ping request, which
+// either side can send to check that the connection is still alive.
+func PingResponse(id jsonrpc.RequestId) any {
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  jsonrpc.Result{},
+	}
+}
+
+// SupportsBatch returns false for protocol versions that removed support for
+// JSON-RPC batches.
+func SupportsBatch(mcpVersion string) bool {
+	return mcpVersion != v20250618.PROTOCOL_VERSION
+}
+
 // NotificationHandler process notifications request. It MUST NOT send a response.
-// Currently Toolbox does not process any notifications.
+// Notifications that depend on the state of the server, such as
+// `notifications/cancelled`, are handled by the server after validation.
 func NotificationHandler(ctx context.Context, body []byte) error {
 	var notification jsonrpc.JSONRPCNotification
 	if err := json.Unmarshal(body, &notification); err != nil {
@@ -86,12 +114,18 @@ func NotificationHandler(ctx context.Context, body []byte) error {
 
 // ProcessMethod returns a response for the request.
 // This is the Operation phase of the lifecycle for MCP client-server connections.
-func ProcessMethod(ctx context.Context, mcpVersion string, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, body []byte) (any, error) {
+func ProcessMethod(ctx context.Context, mcpVersion string, id jsonrpc.RequestId, method string, toolset tools.Toolset, authorizer *tools.Authorizer, pageSize int, tools map[string]tools.Tool, resourcesMap map[string]resources.Resource, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
+	// ping is the same in every protocol version
+	if method == mcputil.PING {
+		return PingResponse(id), nil
+	}
 	switch mcpVersion {
+	case v20250618.PROTOCOL_VERSION:
+		return v20250618.ProcessMethod(ctx, id, method, toolset, authorizer, pageSize, tools, resourcesMap, promptsMap, body)
 	case v20250326.PROTOCOL_VERSION:
-		return v20250326.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20250326.ProcessMethod(ctx, id, method, toolset, authorizer, pageSize, tools, resourcesMap, promptsMap, body)
 	case v20241105.PROTOCOL_VERSION:
-		return v20241105.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20241105.ProcessMethod(ctx, id, method, toolset, authorizer, pageSize, tools, resourcesMap, promptsMap, body)
 	default:
 		err := fmt.Errorf("invalid protocol version: %s", mcpVersion)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
@@ -0,0 +1,24 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+// VerifiedAuthServices returns the names of the auth services in claims.
+func VerifiedAuthServices(claims map[string]map[string]any) []string {
+	verifiedAuthServices := make([]string, 0, len(claims))
+	for name := range claims {
+		verifiedAuthServices = append(verifiedAuthServices, name)
+	}
+	return verifiedAuthServices
+}
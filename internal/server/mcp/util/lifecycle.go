@@ -21,6 +21,7 @@ const (
 	SERVER_NAME = "Toolbox"
 	// methods that are supported
 	INITIALIZE = "initialize"
+	PING       = "ping"
 )
 
 /* Initialization */
@@ -86,6 +87,11 @@ type ClientCapabilities struct {
 // capabilities are defined here, in this schema, but this is not a closed set: any
 // server can define its own, additional capabilities.
 type ServerCapabilities struct {
+	// Present if the server offers any prompt templates.
+	Prompts *ListChanged `json:"prompts,omitempty"`
+	// Present if the server offers any resources to read.
+	Resources *ListChanged `json:"resources,omitempty"`
+	// Present if the server offers any tools to call.
 	Tools *ListChanged `json:"tools,omitempty"`
 }
 
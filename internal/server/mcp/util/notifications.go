@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	// notifications that are supported
+	NOTIFICATIONS_CANCELLED = "notifications/cancelled"
+	NOTIFICATIONS_PROGRESS  = "notifications/progress"
+)
+
+/* Cancellation */
+
+// CancelledNotification can be sent by either side to indicate that it is
+// cancelling a previously-issued request.
+type CancelledNotification struct {
+	jsonrpc.Notification
+	Params struct {
+		// The ID of the request to cancel.
+		RequestId jsonrpc.RequestId `json:"requestId"`
+		// An optional string describing the reason for the cancellation.
+		Reason string `json:"reason,omitempty"`
+	} `json:"params"`
+}
+
+/* Progress */
+
+// ProgressParams are the params of a progress notification.
+type ProgressParams struct {
+	// The progress token which was given in the initial request, used to
+	// associate this notification with the request that is proceeding.
+	ProgressToken jsonrpc.ProgressToken `json:"progressToken"`
+	// The progress thus far. This should increase every time progress is
+	// made, even if the total is unknown.
+	Progress float64 `json:"progress"`
+	// Total number of items to process (or total progress required), if known.
+	Total float64 `json:"total,omitempty"`
+	// An optional message describing the current progress.
+	Message string `json:"message,omitempty"`
+}
+
+// ProgressNotification is an out-of-band notification used to inform the
+// receiver of a progress update for a long-running request.
+type ProgressNotification struct {
+	Jsonrpc string         `json:"jsonrpc"`
+	Method  string         `json:"method"`
+	Params  ProgressParams `json:"params"`
+}
+
+// NotificationSender sends a notification to the client over the transport
+// that the request was received on.
+type NotificationSender func(notification any)
+
+type contextKey string
+
+// notificationSenderKey is the key used to store the notification sender
+// within context
+const notificationSenderKey contextKey = "notificationSender"
+
+// WithNotificationSender adds a notification sender into the context as a value
+func WithNotificationSender(ctx context.Context, send NotificationSender) context.Context {
+	return context.WithValue(ctx, notificationSenderKey, send)
+}
+
+// NotificationSenderFromContext retrieves the notification sender. It returns
+// false if the transport can not send notifications for the request.
+func NotificationSenderFromContext(ctx context.Context) (NotificationSender, bool) {
+	send, ok := ctx.Value(notificationSenderKey).(NotificationSender)
+	return send, ok
+}
+
+// WithProgress adds a progress reporter into the context that sends progress
+// notifications for token, if the transport can send notifications.
+func WithProgress(ctx context.Context, token jsonrpc.ProgressToken) context.Context {
+	if token == nil {
+		return ctx
+	}
+	send, ok := NotificationSenderFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return util.WithProgressReporter(ctx, func(progress, total float64, message string) {
+		send(ProgressNotification{
+			Jsonrpc: jsonrpc.JSONRPC_VERSION,
+			Method:  NOTIFICATIONS_PROGRESS,
+			Params: ProgressParams{
+				ProgressToken: token,
+				Progress:      progress,
+				Total:         total,
+				Message:       message,
+			},
+		})
+	})
+}
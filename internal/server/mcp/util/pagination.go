@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// cursorPrefix versions the cursor format, so that it can be changed without
+// misreading cursors handed out by an older server.
+const cursorPrefix = "v1:"
+
+// EncodeCursor returns an opaque cursor pointing after the item with the given
+// key.
+func EncodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + key))
+}
+
+// DecodeCursor returns the key of the item that a cursor points after.
+func DecodeCursor(cursor string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) < len(cursorPrefix) || string(b[:len(cursorPrefix)]) != cursorPrefix {
+		return "", fmt.Errorf("invalid cursor: %q", cursor)
+	}
+	return string(b[len(cursorPrefix):]), nil
+}
+
+// Paginate returns the page of items that starts after cursor, along with the
+// cursor of the next page. The next cursor is empty on the last page. Items
+// must be in a stable order and key must uniquely identify an item. A
+// pageSize of 0 or less returns all remaining items.
+func Paginate[T any](items []T, cursor string, pageSize int, key func(T) string) ([]T, string, error) {
+	start := 0
+	if cursor != "" {
+		k, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		start = -1
+		for i, item := range items {
+			if key(item) == k {
+				start = i + 1
+				break
+			}
+		}
+		if start == -1 {
+			return nil, "", fmt.Errorf("invalid cursor: %q", cursor)
+		}
+	}
+
+	end := len(items)
+	if pageSize > 0 && start+pageSize < end {
+		end = start + pageSize
+	}
+	page := items[start:end]
+
+	var next string
+	if end < len(items) {
+		next = EncodeCursor(key(items[end-1]))
+	}
+	return page, next, nil
+}
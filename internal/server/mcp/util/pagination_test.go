@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+)
+
+func identity(s string) string { return s }
+
+func TestPaginate(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	tcs := []struct {
+		desc     string
+		pageSize int
+		want     [][]string
+	}{
+		{
+			desc:     "no page size",
+			pageSize: 0,
+			want:     [][]string{{"a", "b", "c", "d", "e"}},
+		},
+		{
+			desc:     "uneven pages",
+			pageSize: 2,
+			want:     [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+		{
+			desc:     "even pages",
+			pageSize: 5,
+			want:     [][]string{{"a", "b", "c", "d", "e"}},
+		},
+		{
+			desc:     "page size larger than items",
+			pageSize: 10,
+			want:     [][]string{{"a", "b", "c", "d", "e"}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got [][]string
+			cursor := ""
+			for {
+				page, next, err := mcputil.Paginate(items, cursor, tc.pageSize, identity)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				got = append(got, page)
+				if next == "" {
+					break
+				}
+				if len(got) > len(items) {
+					t.Fatalf("pagination did not terminate")
+				}
+				cursor = next
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect pages: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestPaginateInvalidCursor(t *testing.T) {
+	items := []string{"a", "b"}
+	for _, cursor := range []string{"not-base64!", "Zm9v", mcputil.EncodeCursor("z")} {
+		t.Run(cursor, func(t *testing.T) {
+			if _, _, err := mcputil.Paginate(items, cursor, 1, identity); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -19,34 +19,68 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 // ProcessMethod returns a response for the request.
-func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, body []byte) (any, error) {
+func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, authorizer *tools.Authorizer, pageSize int, tools map[string]tools.Tool, resourcesMap map[string]resources.Resource, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
 	switch method {
 	case TOOLS_LIST:
-		return toolsListHandler(id, toolset, body)
+		return toolsListHandler(ctx, id, toolset, authorizer, pageSize, body)
 	case TOOLS_CALL:
-		return toolsCallHandler(ctx, id, tools, body)
+		return toolsCallHandler(ctx, id, toolset, authorizer, tools, body)
+	case RESOURCES_LIST:
+		return resourcesListHandler(id, visibleResources(ctx, toolset, authorizer, resourcesMap), body)
+	case RESOURCES_TEMPLATES_LIST:
+		return resourcesTemplatesListHandler(id, visibleResources(ctx, toolset, authorizer, resourcesMap), body)
+	case RESOURCES_READ:
+		return resourcesReadHandler(ctx, id, visibleResources(ctx, toolset, authorizer, resourcesMap), body)
+	case PROMPTS_LIST:
+		return promptsListHandler(id, promptsMap, body)
+	case PROMPTS_GET:
+		return promptsGetHandler(ctx, id, promptsMap, body)
 	default:
 		err := fmt.Errorf("invalid method %s", method)
 		return jsonrpc.NewError(id, jsonrpc.METHOD_NOT_FOUND, err.Error(), nil), err
 	}
 }
 
-func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte) (any, error) {
+func toolsListHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, authorizer *tools.Authorizer, pageSize int, body []byte) (any, error) {
 	var req ListToolsRequest
 	if err := json.Unmarshal(body, &req); err != nil {
 		err = fmt.Errorf("invalid mcp tools list request: %w", err)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
 
+	// only list the tools that the caller is allowed to use
+	visible := authorizer.McpManifest(toolset, util.AuthClaimsFromContext(ctx))
+	manifests, next, err := mcputil.Paginate(visible, string(req.Params.Cursor), pageSize, func(m tools.McpManifest) string { return m.Name })
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	if manifests == nil {
+		manifests = []tools.McpManifest{}
+	}
+	// title, outputSchema and annotations are only part of later protocol
+	// versions
+	manifests = slices.Clone(manifests)
+	for i := range manifests {
+		manifests[i].Title = ""
+		manifests[i].OutputSchema = nil
+		manifests[i].Annotations = nil
+	}
+
 	result := ListToolsResult{
-		Tools: toolset.McpManifest,
+		PaginatedResult: PaginatedResult{NextCursor: Cursor(next)},
+		Tools:           manifests,
 	}
 	return jsonrpc.JSONRPCResponse{
 		Jsonrpc: jsonrpc.JSONRPC_VERSION,
@@ -56,7 +90,7 @@ func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte)
 }
 
 // toolsCallHandler generate a response for tools call.
-func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[string]tools.Tool, body []byte) (any, error) {
+func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, toolset tools.Toolset, authorizer *tools.Authorizer, tools map[string]tools.Tool, body []byte) (any, error) {
 	// retrieve logger from context
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -77,6 +111,10 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
 		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
 	}
+	if _, ok := toolset.Manifest.ToolsManifest[toolName]; !ok {
+		err = fmt.Errorf("invalid tool name: tool %q is not part of toolset %q", toolName, toolset.Name)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
 
 	// marshal arguments and decode it using decodeJSON instead to prevent loss between floats/int.
 	aMarshal, err := json.Marshal(toolArgument)
@@ -91,9 +129,10 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
 	}
 
-	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
-	// Since MCP doesn't support auth, an empty map will be use every time.
-	claimsFromAuth := make(map[string]map[string]any)
+	// claimsFromAuth maps the name of the authservice to the claims retrieved
+	// from the headers of the request, or from the request that started the
+	// session.
+	claimsFromAuth := util.AuthClaimsFromContext(ctx)
 
 	params, err := tool.ParseParams(data, claimsFromAuth)
 	if err != nil {
@@ -102,10 +141,17 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 	}
 	logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
-	if !tool.Authorized([]string{}) {
+	if !tool.Authorized(mcputil.VerifiedAuthServices(claimsFromAuth)) {
 		err = fmt.Errorf("unauthorized Tool call: `authRequired` is set for the target Tool")
 		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 	}
+	if err = authorizer.Authorize(toolset.Name, toolName, claimsFromAuth); err != nil {
+		err = fmt.Errorf("unauthorized Tool call: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	// report progress to the client if it asked for it
+	ctx = mcputil.WithProgress(ctx, req.Params.Meta.ProgressToken)
 
 	// run tool invocation and generate response.
 	results, err := tool.Invoke(ctx, params)
@@ -139,3 +185,186 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 		Result:  CallToolResult{Content: content},
 	}, nil
 }
+
+// visibleResources returns the resources that the caller can read through
+// the toolset, with the same authorization as `tools/call`.
+func visibleResources(ctx context.Context, toolset tools.Toolset, authorizer *tools.Authorizer, resourcesMap map[string]resources.Resource) map[string]resources.Resource {
+	return resources.Visible(resourcesMap, toolset, authorizer, util.AuthClaimsFromContext(ctx))
+}
+
+// sortedResources returns the resources ordered by name, so that listing and
+// uri matching are deterministic.
+func sortedResources(resourcesMap map[string]resources.Resource) []resources.Resource {
+	names := make([]string, 0, len(resourcesMap))
+	for name := range resourcesMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rs := make([]resources.Resource, 0, len(names))
+	for _, name := range names {
+		rs = append(rs, resourcesMap[name])
+	}
+	return rs
+}
+
+func resourcesListHandler(id jsonrpc.RequestId, resourcesMap map[string]resources.Resource, body []byte) (any, error) {
+	var req ListResourcesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	manifests := make([]resources.McpManifest, 0)
+	for _, r := range sortedResources(resourcesMap) {
+		if !r.IsTemplate() {
+			manifests = append(manifests, r.McpManifest())
+		}
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListResourcesResult{Resources: manifests},
+	}, nil
+}
+
+func resourcesTemplatesListHandler(id jsonrpc.RequestId, resourcesMap map[string]resources.Resource, body []byte) (any, error) {
+	var req ListResourceTemplatesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources templates list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	manifests := make([]resources.McpTemplateManifest, 0)
+	for _, r := range sortedResources(resourcesMap) {
+		if r.IsTemplate() {
+			manifests = append(manifests, r.McpTemplateManifest())
+		}
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListResourceTemplatesResult{ResourceTemplates: manifests},
+	}, nil
+}
+
+// resourcesReadHandler generate a response for resources read.
+func resourcesReadHandler(ctx context.Context, id jsonrpc.RequestId, resourcesMap map[string]resources.Resource, body []byte) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+
+	var req ReadResourceRequest
+	if err = json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources read request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	uri := req.Params.URI
+	logger.DebugContext(ctx, fmt.Sprintf("resource uri: %s", uri))
+
+	// resources with a fixed uri take precedence over templates
+	var resource *resources.Resource
+	rs := sortedResources(resourcesMap)
+	for _, r := range rs {
+		if _, ok := r.Match(uri); ok && !r.IsTemplate() {
+			resource = &r
+			break
+		}
+	}
+	if resource == nil {
+		for _, r := range rs {
+			if _, ok := r.Match(uri); ok {
+				resource = &r
+				break
+			}
+		}
+	}
+	if resource == nil {
+		err = fmt.Errorf("resource not found: %s", uri)
+		return jsonrpc.NewError(id, RESOURCE_NOT_FOUND, err.Error(), map[string]any{"uri": uri}), err
+	}
+
+	text, err := resource.Read(ctx, uri)
+	if err != nil {
+		err = fmt.Errorf("unable to read resource %q: %w", uri, err)
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+
+	content := TextResourceContents{
+		URI:      uri,
+		MimeType: resource.MimeType,
+		Text:     text,
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ReadResourceResult{Contents: []TextResourceContents{content}},
+	}, nil
+}
+
+func promptsListHandler(id jsonrpc.RequestId, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
+	var req ListPromptsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp prompts list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	names := make([]string, 0, len(promptsMap))
+	for name := range promptsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	manifests := make([]prompts.McpManifest, 0, len(names))
+	for _, name := range names {
+		manifests = append(manifests, promptsMap[name].McpManifest())
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListPromptsResult{Prompts: manifests},
+	}, nil
+}
+
+// promptsGetHandler generate a response for prompts get.
+func promptsGetHandler(ctx context.Context, id jsonrpc.RequestId, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
+	// retrieve logger from context
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+
+	var req GetPromptRequest
+	if err = json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp prompts get request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	promptName := req.Params.Name
+	logger.DebugContext(ctx, fmt.Sprintf("prompt name: %s", promptName))
+	prompt, ok := promptsMap[promptName]
+	if !ok {
+		err = fmt.Errorf("invalid prompt name: prompt with name %q does not exist", promptName)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+
+	messages, err := prompt.Render(req.Params.Arguments)
+	if err != nil {
+		err = fmt.Errorf("provided arguments were invalid: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+
+	promptMessages := make([]PromptMessage, 0, len(messages))
+	for _, m := range messages {
+		promptMessages = append(promptMessages, PromptMessage{
+			Role:    Role(m.Role),
+			Content: TextContent{Type: "text", Text: m.Text},
+		})
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  GetPromptResult{Description: prompt.Description, Messages: promptMessages},
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+)
+
+// errRequestCancelled is the cause of the context of a request cancelled by
+// the client.
+var errRequestCancelled = errors.New("request cancelled by client")
+
+// inflightRequest is a request that is being processed.
+type inflightRequest struct {
+	cancel context.CancelCauseFunc
+}
+
+// requestTracker tracks in-flight requests, so that clients can cancel them
+// with `notifications/cancelled`. Request ids are only unique within a
+// session, so requests are tracked per session, or per HTTP request for
+// clients without a session. The zero value is ready to use.
+type requestTracker struct {
+	mu       sync.Mutex
+	inflight map[string]*inflightRequest
+}
+
+func requestKey(sessionId string, id jsonrpc.RequestId) string {
+	return fmt.Sprintf("%s/%v", sessionId, id)
+}
+
+// track returns a context that is cancelled when the client cancels the
+// request. done must be called once the request is processed.
+func (t *requestTracker) track(ctx context.Context, sessionId string, id jsonrpc.RequestId) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	req := &inflightRequest{cancel: cancel}
+	key := requestKey(sessionId, id)
+
+	t.mu.Lock()
+	if t.inflight == nil {
+		t.inflight = make(map[string]*inflightRequest)
+	}
+	t.inflight[key] = req
+	t.mu.Unlock()
+
+	done := func() {
+		t.mu.Lock()
+		// the id might have been reused by a newer request
+		if t.inflight[key] == req {
+			delete(t.inflight, key)
+		}
+		t.mu.Unlock()
+		cancel(nil)
+	}
+	return ctx, done
+}
+
+// cancel cancels an in-flight request. It returns false if the request is
+// not in flight.
+func (t *requestTracker) cancel(sessionId string, id jsonrpc.RequestId) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	req, ok := t.inflight[requestKey(sessionId, id)]
+	if !ok {
+		return false
+	}
+	req.cancel(errRequestCancelled)
+	return true
+}
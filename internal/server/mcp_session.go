@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+)
+
+// defaultSessionTTL is how long an idle streamable HTTP session is kept when
+// no TTL is configured.
+const defaultSessionTTL = 30 * time.Minute
+
+// mcpSession is a streamable HTTP session, identified by the `Mcp-Session-Id`
+// header. It keeps the state negotiated during initialization. A session
+// belongs to the toolset it was initialized for.
+type mcpSession struct {
+	id                 string
+	protocolVersion    string
+	toolsetName        string
+	clientCapabilities mcputil.ClientCapabilities
+	// claims and tokens of the bearer tokens verified from the initialize
+	// request, and when they expire
+	claims     map[string]map[string]any
+	tokens     map[string]string
+	expiries   map[string]time.Time
+	lastActive time.Time
+}
+
+// sessionManager manages and control access to streamable HTTP sessions
+type sessionManager struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[string]*mcpSession
+}
+
+func newSessionManager(ctx context.Context, ttl time.Duration) *sessionManager {
+	if ttl <= 0 {
+		ttl = defaultSessionTTL
+	}
+	m := &sessionManager{
+		mu:       sync.Mutex{},
+		ttl:      ttl,
+		sessions: make(map[string]*mcpSession),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+// create starts a new session for an initialized client.
+func (m *sessionManager) create(protocolVersion, toolsetName string, capabilities mcputil.ClientCapabilities, claims map[string]map[string]any, tokens map[string]string) *mcpSession {
+	session := &mcpSession{
+		id:                 uuid.New().String(),
+		protocolVersion:    protocolVersion,
+		toolsetName:        toolsetName,
+		clientCapabilities: capabilities,
+		claims:             claims,
+		tokens:             tokens,
+		expiries:           claimsExpiries(claims),
+		lastActive:         time.Now(),
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.id] = session
+	return session
+}
+
+// get returns an active session of a toolset and extends its lifetime.
+// Expired sessions are removed.
+func (m *sessionManager) get(id, toolsetName string) (*mcpSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if !ok || session.toolsetName != toolsetName {
+		return nil, false
+	}
+	now := time.Now()
+	if now.Sub(session.lastActive) > m.ttl {
+		delete(m.sessions, id)
+		return nil, false
+	}
+	session.lastActive = now
+	return session, true
+}
+
+// remove ends a session of a toolset. It returns false if the session does
+// not exist.
+func (m *sessionManager) remove(id, toolsetName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if session, ok := m.sessions[id]; !ok || session.toolsetName != toolsetName {
+		return false
+	}
+	delete(m.sessions, id)
+	return true
+}
+
+func (m *sessionManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(m.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				now := time.Now()
+				for id, session := range m.sessions {
+					if now.Sub(session.lastActive) > m.ttl {
+						delete(m.sessions, id)
+					}
+				}
+			}()
+		}
+	}
+}
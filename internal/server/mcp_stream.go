@@ -0,0 +1,221 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// streamKeepAliveInterval is how often a comment is sent on an idle
+	// stream, so that proxies don't time out long running requests.
+	streamKeepAliveInterval = 15 * time.Second
+	// streamRetention is how long a stream can be resumed after it was last
+	// active.
+	streamRetention = 10 * time.Minute
+	// maxStreamEvents is the number of events kept for resumption per stream.
+	maxStreamEvents = 100
+)
+
+// streamEvent is a message sent on a stream of the streamable HTTP transport.
+type streamEvent struct {
+	seq  int
+	data []byte
+}
+
+// mcpStream is an SSE stream of the streamable HTTP transport. Events are kept
+// after they are sent, so that a client can resume the stream with the
+// `Last-Event-ID` header after a disconnection. A stream belongs to the
+// session it was opened in, and can only be resumed within that session.
+type mcpStream struct {
+	id        string
+	sessionId string
+
+	mu         sync.Mutex
+	events     []streamEvent
+	nextSeq    int
+	closed     bool
+	changed    chan struct{}
+	lastActive time.Time
+}
+
+func newMcpStream(sessionId string) *mcpStream {
+	return &mcpStream{
+		id:         uuid.New().String(),
+		sessionId:  sessionId,
+		nextSeq:    1,
+		changed:    make(chan struct{}),
+		lastActive: time.Now(),
+	}
+}
+
+// eventId returns the id of the event with seq. Event ids are unique across
+// all streams, so that they identify the stream to resume.
+func (st *mcpStream) eventId(seq int) string {
+	return fmt.Sprintf("%s:%d", st.id, seq)
+}
+
+// send adds a message to the stream and wakes up its writers.
+func (st *mcpStream) send(data []byte) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.closed {
+		return
+	}
+	st.events = append(st.events, streamEvent{seq: st.nextSeq, data: data})
+	if len(st.events) > maxStreamEvents {
+		st.events = st.events[len(st.events)-maxStreamEvents:]
+	}
+	st.nextSeq++
+	st.lastActive = time.Now()
+	close(st.changed)
+	st.changed = make(chan struct{})
+}
+
+// close marks that no more messages will be sent on the stream.
+func (st *mcpStream) close() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.closed {
+		return
+	}
+	st.closed = true
+	st.lastActive = time.Now()
+	close(st.changed)
+}
+
+// eventsAfter returns the events sent after seq, whether the stream is
+// closed, and a channel that is closed when the stream changes.
+func (st *mcpStream) eventsAfter(seq int) ([]streamEvent, bool, <-chan struct{}) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.lastActive = time.Now()
+	var events []streamEvent
+	for _, e := range st.events {
+		if e.seq > seq {
+			events = append(events, e)
+		}
+	}
+	return events, st.closed, st.changed
+}
+
+// write sends the events after seq to w until the stream is closed or ctx is
+// done. Keep-alive comments are sent while the stream is idle.
+func (st *mcpStream) write(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, seq int) error {
+	ticker := time.NewTicker(streamKeepAliveInterval)
+	defer ticker.Stop()
+	for {
+		events, closed, changed := st.eventsAfter(seq)
+		for _, e := range events {
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: message\ndata: %s\n\n", st.eventId(e.seq), e.data); err != nil {
+				return err
+			}
+			seq = e.seq
+		}
+		flusher.Flush()
+		if closed {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// streamManager manages and control access to the streams of the streamable
+// HTTP transport.
+type streamManager struct {
+	mu      sync.Mutex
+	streams map[string]*mcpStream
+}
+
+func newStreamManager(ctx context.Context) *streamManager {
+	m := &streamManager{
+		mu:      sync.Mutex{},
+		streams: make(map[string]*mcpStream),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+// newStream creates and registers a new stream of a session.
+func (m *streamManager) newStream(sessionId string) *mcpStream {
+	st := newMcpStream(sessionId)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.streams[st.id] = st
+	return st
+}
+
+// resume returns the stream and the sequence number that a `Last-Event-ID`
+// refers to. Streams of other sessions are not found.
+func (m *streamManager) resume(lastEventId, sessionId string) (*mcpStream, int, error) {
+	idx := strings.LastIndex(lastEventId, ":")
+	if idx == -1 {
+		return nil, 0, fmt.Errorf("invalid Last-Event-ID: %q", lastEventId)
+	}
+	seq, err := strconv.Atoi(lastEventId[idx+1:])
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid Last-Event-ID: %q", lastEventId)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.streams[lastEventId[:idx]]
+	if !ok || st.sessionId != sessionId {
+		return nil, 0, fmt.Errorf("stream not found for Last-Event-ID: %q", lastEventId)
+	}
+	return st, seq, nil
+}
+
+func (m *streamManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(streamRetention)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				now := time.Now()
+				for id, st := range m.streams {
+					st.mu.Lock()
+					expired := now.Sub(st.lastActive) > streamRetention
+					st.mu.Unlock()
+					if expired {
+						delete(m.streams, id)
+					}
+				}
+			}()
+		}
+	}
+}
@@ -20,22 +20,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/hmac"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 const jsonrpcVersion = "2.0"
 const protocolVersion20241105 = "2024-11-05"
 const protocolVersion20250326 = "2025-03-26"
+const protocolVersion20250618 = "2025-06-18"
 const serverName = "Toolbox"
 
 var tool1InputSchema = map[string]any{
@@ -239,6 +248,33 @@ func runInitializeLifecycle(t *testing.T, ts *httptest.Server, protocolVersion s
 	return sessionId
 }
 
+// initializeSession initializes a v2025-03-26 client and returns its session id.
+func initializeSession(t *testing.T, ts *httptest.Server) string {
+	return initializeToolsetSession(t, ts, "/")
+}
+
+// initializeToolsetSession starts a session for the toolset at path.
+func initializeToolsetSession(t *testing.T, ts *httptest.Server, path string) string {
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "mcp-initialize",
+		"method":  "initialize",
+		"params":  map[string]any{"protocolVersion": protocolVersion20250326},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	resp, _, err := runRequest(ts, http.MethodPost, path, bytes.NewBuffer(reqMarshal), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	sessionId := resp.Header.Get("Mcp-Session-Id")
+	if sessionId == "" {
+		t.Fatalf("Mcp-Session-Id header is expected")
+	}
+	return sessionId
+}
+
 func TestMcpEndpoint(t *testing.T) {
 	mockTools := []MockTool{tool1, tool2, tool3}
 	toolsMap, toolsets := setUpResources(t, mockTools)
@@ -263,7 +299,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2024-11-05",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"prompts":   map[string]any{"listChanged": false},
+						"resources": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -279,7 +317,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2025-03-26",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"prompts":   map[string]any{"listChanged": false},
+						"resources": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -312,6 +352,22 @@ func TestMcpEndpoint(t *testing.T) {
 						},
 					},
 				},
+				{
+					name: "ping",
+					url:  "/",
+					body: jsonrpc.JSONRPCRequest{
+						Jsonrpc: jsonrpcVersion,
+						Id:      "ping",
+						Request: jsonrpc.Request{
+							Method: "ping",
+						},
+					},
+					want: map[string]any{
+						"jsonrpc": "2.0",
+						"id":      "ping",
+						"result":  map[string]any{},
+					},
+				},
 				{
 					name: "tools/list",
 					url:  "/",
@@ -446,30 +502,15 @@ func TestMcpEndpoint(t *testing.T) {
 					},
 				},
 				{
-					name:  "batch requests",
+					name:  "empty batch",
 					url:   "/",
 					isErr: true,
-					body: []any{
-						jsonrpc.JSONRPCRequest{
-							Jsonrpc: "1.0",
-							Id:      "batch-requests1",
-							Request: jsonrpc.Request{
-								Method: "foo",
-							},
-						},
-						jsonrpc.JSONRPCRequest{
-							Jsonrpc: jsonrpcVersion,
-							Id:      "batch-requests2",
-							Request: jsonrpc.Request{
-								Method: "tools/list",
-							},
-						},
-					},
+					body:  []any{},
 					want: map[string]any{
 						"jsonrpc": "2.0",
 						"error": map[string]any{
 							"code":    -32600.0,
-							"message": "not supporting batch requests",
+							"message": "batch must contain at least one message",
 						},
 					},
 				},
@@ -484,6 +525,11 @@ func TestMcpEndpoint(t *testing.T) {
 					if vtc.protocol == protocolVersion20250326 && len(header) == 0 {
 						t.Fatalf("header is missing")
 					}
+					header := header
+					// sessions belong to the toolset they were initialized for
+					if vtc.idHeader && tc.url != "/" {
+						header = map[string]string{"Mcp-Session-Id": initializeToolsetSession(t, ts, tc.url)}
+					}
 
 					resp, body, err := runRequest(ts, http.MethodPost, tc.url, bytes.NewBuffer(reqMarshal), header)
 					if err != nil {
@@ -515,18 +561,138 @@ func TestMcpEndpoint(t *testing.T) {
 }
 
 func TestDeleteEndpoint(t *testing.T) {
-	toolsMap, toolsets := map[string]tools.Tool{}, map[string]tools.Toolset{}
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
 	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
 	defer shutdown()
 	ts := runServer(r, false)
 	defer ts.Close()
 
-	resp, _, err := runRequest(ts, http.MethodDelete, "/", nil, nil)
-	if resp.Status != "200 OK" {
-		t.Fatalf("unexpected status: %s", resp.Status)
-	}
+	sessionId := initializeSession(t, ts)
+	header := map[string]string{"Mcp-Session-Id": sessionId}
+	toolsList, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-list",
+		"method":  "tools/list",
+	})
 	if err != nil {
-		t.Fatalf("unexpected error during request: %s", err)
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+
+	steps := []struct {
+		desc       string
+		method     string
+		header     map[string]string
+		body       []byte
+		wantStatus int
+	}{
+		{desc: "missing session id", method: http.MethodDelete, wantStatus: http.StatusBadRequest},
+		{desc: "request in session", method: http.MethodPost, header: header, body: toolsList, wantStatus: http.StatusOK},
+		{desc: "delete session", method: http.MethodDelete, header: header, wantStatus: http.StatusOK},
+		{desc: "request after delete", method: http.MethodPost, header: header, body: toolsList, wantStatus: http.StatusNotFound},
+		{desc: "delete again", method: http.MethodDelete, header: header, wantStatus: http.StatusNotFound},
+	}
+	for _, step := range steps {
+		resp, _, err := runRequest(ts, step.method, "/", bytes.NewBuffer(step.body), step.header)
+		if err != nil {
+			t.Fatalf("%s: unexpected error during request: %s", step.desc, err)
+		}
+		if resp.StatusCode != step.wantStatus {
+			t.Fatalf("%s: unexpected status: got %d, want %d", step.desc, resp.StatusCode, step.wantStatus)
+		}
+	}
+}
+
+func TestMcpSession(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	t.Run("unknown session", func(t *testing.T) {
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`), map[string]string{"Mcp-Session-Id": "foo"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("unexpected status: %s", resp.Status)
+		}
+	})
+
+	t.Run("session id is only issued on initialize", func(t *testing.T) {
+		sessionId := initializeSession(t, ts)
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`), map[string]string{"Mcp-Session-Id": sessionId})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if got := resp.Header.Get("Mcp-Session-Id"); got != "" {
+			t.Fatalf("unexpected Mcp-Session-Id header: %s", got)
+		}
+	})
+
+	t.Run("missing session id", func(t *testing.T) {
+		for _, v := range []string{protocolVersion20250326, protocolVersion20250618} {
+			resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`), map[string]string{"MCP-Protocol-Version": v})
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("%s: unexpected status: %s", v, resp.Status)
+			}
+		}
+	})
+
+	t.Run("session of another toolset", func(t *testing.T) {
+		sessionId := initializeToolsetSession(t, ts, "/tool1_only")
+		header := map[string]string{"Mcp-Session-Id": sessionId}
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("unexpected status: %s", resp.Status)
+		}
+		resp, _, err = runRequest(ts, http.MethodPost, "/tool1_only", bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %s", resp.Status)
+		}
+	})
+}
+
+func TestSessionManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := newSessionManager(ctx, 50*time.Millisecond)
+
+	listChanged := true
+	capabilities := mcputil.ClientCapabilities{Roots: &mcputil.ListChanged{ListChanged: &listChanged}}
+	session := m.create(protocolVersion20250326, "", capabilities, nil, nil)
+
+	got, ok := m.get(session.id, "")
+	if !ok {
+		t.Fatalf("session should exist")
+	}
+	if got.protocolVersion != protocolVersion20250326 {
+		t.Fatalf("unexpected protocol version: %s", got.protocolVersion)
+	}
+	if !reflect.DeepEqual(got.clientCapabilities, capabilities) {
+		t.Fatalf("unexpected client capabilities: %+v", got.clientCapabilities)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if _, ok := m.get(session.id, ""); ok {
+		t.Fatalf("session should have expired")
+	}
+
+	session = m.create(protocolVersion20250326, "", capabilities, nil, nil)
+	if !m.remove(session.id, "") {
+		t.Fatalf("session should have been removed")
+	}
+	if m.remove(session.id, "") {
+		t.Fatalf("session should not exist")
 	}
 }
 
@@ -545,7 +711,7 @@ func TestGetEndpoint(t *testing.T) {
 	if err := json.Unmarshal(body, &got); err != nil {
 		t.Fatalf("unexpected error unmarshalling body: %s", err)
 	}
-	want := "toolbox does not support streaming in streamable HTTP transport"
+	want := "streamable HTTP GET requests must accept text/event-stream"
 	if got["error"] != want {
 		t.Fatalf("unexpected error message: %s", got["error"])
 	}
@@ -699,8 +865,10 @@ func TestStdioSession(t *testing.T) {
 	}
 
 	sseManager := newSseManager(ctx)
+	streamManager := newStreamManager(ctx)
+	sessionManager := newSessionManager(ctx, 0)
 
-	server := &Server{version: fakeVersionString, logger: testLogger, instrumentation: instrumentation, sseManager: sseManager, tools: toolsMap, toolsets: toolsets}
+	server := &Server{version: fakeVersionString, logger: testLogger, instrumentation: instrumentation, sseManager: sseManager, streamManager: streamManager, sessionManager: sessionManager, tools: toolsMap, toolsets: toolsets}
 
 	in := bufio.NewReader(pr)
 	stdioSession := NewStdioSession(server, in, pw)
@@ -736,3 +904,1628 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unexpected read: got %s, want %s", read, want)
 	}
 }
+
+func TestStdioConcurrentRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tool := blockingTool{MockTool: MockTool{Name: "blocking"}, started: make(chan struct{})}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+
+	testLogger, err := log.NewStdLogger(io.Discard, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	server := &Server{version: fakeVersionString, logger: testLogger, instrumentation: instrumentation, tools: toolsMap, toolsets: map[string]tools.Toolset{"": toolset}}
+	ctx = util.WithLogger(ctx, testLogger)
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+	stdioSession := NewStdioSession(server, stdinR, stdoutW)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- stdioSession.Start(ctx)
+	}()
+	out := bufio.NewReader(stdoutR)
+
+	send := func(message map[string]any) {
+		b, err := json.Marshal(message)
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of message")
+		}
+		if _, err := fmt.Fprintf(stdinW, "%s\n", b); err != nil {
+			t.Fatalf("error writing into stdin: %s", err)
+		}
+	}
+	receive := func() map[string]any {
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatalf("error reading from stdout: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling line: %s", err)
+		}
+		return got
+	}
+
+	send(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "mcp-initialize",
+		"method":  "initialize",
+		"params":  map[string]any{"protocolVersion": protocolVersion20250326},
+	})
+	if got := receive(); got["id"] != "mcp-initialize" {
+		t.Fatalf("unexpected initialize response: %+v", got)
+	}
+
+	// the ping is answered while the tool call is still running
+	send(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool.Name},
+	})
+	select {
+	case <-tool.started:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("tool was not invoked")
+	}
+	send(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "ping",
+		"method":  "ping",
+	})
+	want := map[string]any{"jsonrpc": "2.0", "id": "ping", "result": map[string]any{}}
+	if got := receive(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected response: got %+v, want %+v", got, want)
+	}
+
+	// cancelled requests don't have a response, so the session ends as soon
+	// as the tool call is cancelled and stdin is closed
+	send(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"method":  "notifications/cancelled",
+		"params":  map[string]any{"requestId": "tools-call"},
+	})
+	if err := stdinW.Close(); err != nil {
+		t.Fatalf("error closing stdin: %s", err)
+	}
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("unexpected error from stdio session: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stdio session did not end")
+	}
+}
+
+func TestMcpResources(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2, tool3}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+
+	resourceConfigs := []resources.Config{
+		{Name: "notes", URI: "toolbox://notes", Description: "some notes", Text: "hello"},
+		{Name: "no_params_result", URI: "toolbox://no_params", Tool: "no_params"},
+		{Name: "some_params_result", URITemplate: "toolbox://some_params/{param1}/{param2}", Tool: "some_params"},
+	}
+	resourcesMap := make(map[string]resources.Resource)
+	for _, rc := range resourceConfigs {
+		res, err := rc.Initialize(toolsMap)
+		if err != nil {
+			t.Fatalf("unable to initialize resource %q: %s", rc.Name, err)
+		}
+		resourcesMap[rc.Name] = res
+	}
+
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, withResources(resourcesMap))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name string
+		body map[string]any
+		want map[string]any
+	}{
+		{
+			name: "resources/list",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "resources-list",
+				"method":  "resources/list",
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "resources-list",
+				"result": map[string]any{
+					"resources": []any{
+						map[string]any{"uri": "toolbox://no_params", "name": "no_params_result", "mimeType": "application/json"},
+						map[string]any{"uri": "toolbox://notes", "name": "notes", "description": "some notes", "mimeType": "text/plain"},
+					},
+				},
+			},
+		},
+		{
+			name: "resources/templates/list",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "resources-templates-list",
+				"method":  "resources/templates/list",
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "resources-templates-list",
+				"result": map[string]any{
+					"resourceTemplates": []any{
+						map[string]any{"uriTemplate": "toolbox://some_params/{param1}/{param2}", "name": "some_params_result", "mimeType": "application/json"},
+					},
+				},
+			},
+		},
+		{
+			name: "resources/read text",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "resources-read-text",
+				"method":  "resources/read",
+				"params":  map[string]any{"uri": "toolbox://notes"},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "resources-read-text",
+				"result": map[string]any{
+					"contents": []any{
+						map[string]any{"uri": "toolbox://notes", "mimeType": "text/plain", "text": "hello"},
+					},
+				},
+			},
+		},
+		{
+			name: "resources/read tool",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "resources-read-tool",
+				"method":  "resources/read",
+				"params":  map[string]any{"uri": "toolbox://no_params"},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "resources-read-tool",
+				"result": map[string]any{
+					"contents": []any{
+						map[string]any{"uri": "toolbox://no_params", "mimeType": "application/json", "text": `["no_params"]`},
+					},
+				},
+			},
+		},
+		{
+			name: "resources/read template",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "resources-read-template",
+				"method":  "resources/read",
+				"params":  map[string]any{"uri": "toolbox://some_params/1/2"},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "resources-read-template",
+				"result": map[string]any{
+					"contents": []any{
+						map[string]any{"uri": "toolbox://some_params/1/2", "mimeType": "application/json", "text": `["some_params"]`},
+					},
+				},
+			},
+		},
+		{
+			name: "resources/read not found",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "resources-read-not-found",
+				"method":  "resources/read",
+				"params":  map[string]any{"uri": "toolbox://foo"},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "resources-read-not-found",
+				"error": map[string]any{
+					"code":    -32002.0,
+					"message": "resource not found: toolbox://foo",
+					"data":    map[string]any{"uri": "toolbox://foo"},
+				},
+			},
+		},
+	}
+	sessionId := initializeSession(t, ts)
+	for _, protocol := range []string{protocolVersion20241105, protocolVersion20250326} {
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("%s %s", protocol, tc.name), func(t *testing.T) {
+				header := map[string]string{}
+				if protocol == protocolVersion20250326 {
+					header["Mcp-Session-Id"] = sessionId
+				}
+				reqMarshal, err := json.Marshal(tc.body)
+				if err != nil {
+					t.Fatalf("unexpected error during marshaling of body")
+				}
+				_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				var got map[string]any
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unexpected error unmarshalling body: %s", err)
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("unexpected response: got %+v, want %+v", got, tc.want)
+				}
+			})
+		}
+	}
+
+	t.Run("resources of tools outside the toolset", func(t *testing.T) {
+		header := map[string]string{"Mcp-Session-Id": initializeToolsetSession(t, ts, "/tool1_only")}
+		reqMarshal, err := json.Marshal(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "resources-read-toolset",
+			"method":  "resources/read",
+			"params":  map[string]any{"uri": "toolbox://some_params/1/2"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		_, body, err := runRequest(ts, http.MethodPost, "/tool1_only", bytes.NewBuffer(reqMarshal), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if !strings.Contains(string(body), "resource not found") {
+			t.Fatalf("expected resource not found, got %s", string(body))
+		}
+	})
+}
+
+func TestMcpPrompts(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2, tool3}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+
+	promptConfigs := []prompts.Config{
+		{
+			Name:        "analyze_table",
+			Description: "analyze a table",
+			Arguments: tools.Parameters{
+				tools.NewStringParameter("table", "name of the table"),
+				tools.NewIntParameterWithDefault("limit", 10, "number of rows"),
+			},
+			Messages: []prompts.MessageConfig{
+				{Text: "Analyze the first {{.limit}} rows of {{.table}}."},
+				{Role: "assistant", Text: "I will call the tools to read {{.table}}."},
+			},
+		},
+		{
+			Name:     "hello",
+			Messages: []prompts.MessageConfig{{Text: "Hello!"}},
+		},
+	}
+	promptsMap := make(map[string]prompts.Prompt)
+	for _, pc := range promptConfigs {
+		p, err := pc.Initialize()
+		if err != nil {
+			t.Fatalf("unable to initialize prompt %q: %s", pc.Name, err)
+		}
+		promptsMap[pc.Name] = p
+	}
+
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, withPrompts(promptsMap))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name string
+		body map[string]any
+		want map[string]any
+	}{
+		{
+			name: "prompts/list",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "prompts-list",
+				"method":  "prompts/list",
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "prompts-list",
+				"result": map[string]any{
+					"prompts": []any{
+						map[string]any{
+							"name":        "analyze_table",
+							"description": "analyze a table",
+							"arguments": []any{
+								map[string]any{"name": "table", "description": "name of the table", "required": true},
+								map[string]any{"name": "limit", "description": "number of rows"},
+							},
+						},
+						map[string]any{"name": "hello"},
+					},
+				},
+			},
+		},
+		{
+			name: "prompts/get",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "prompts-get",
+				"method":  "prompts/get",
+				"params": map[string]any{
+					"name":      "analyze_table",
+					"arguments": map[string]any{"table": "orders", "limit": "5"},
+				},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "prompts-get",
+				"result": map[string]any{
+					"description": "analyze a table",
+					"messages": []any{
+						map[string]any{"role": "user", "content": map[string]any{"type": "text", "text": "Analyze the first 5 rows of orders."}},
+						map[string]any{"role": "assistant", "content": map[string]any{"type": "text", "text": "I will call the tools to read orders."}},
+					},
+				},
+			},
+		},
+		{
+			name: "prompts/get with default",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "prompts-get-default",
+				"method":  "prompts/get",
+				"params": map[string]any{
+					"name":      "analyze_table",
+					"arguments": map[string]any{"table": "orders"},
+				},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "prompts-get-default",
+				"result": map[string]any{
+					"description": "analyze a table",
+					"messages": []any{
+						map[string]any{"role": "user", "content": map[string]any{"type": "text", "text": "Analyze the first 10 rows of orders."}},
+						map[string]any{"role": "assistant", "content": map[string]any{"type": "text", "text": "I will call the tools to read orders."}},
+					},
+				},
+			},
+		},
+		{
+			name: "prompts/get missing argument",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "prompts-get-missing",
+				"method":  "prompts/get",
+				"params":  map[string]any{"name": "analyze_table"},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "prompts-get-missing",
+				"error": map[string]any{
+					"code":    -32602.0,
+					"message": `provided arguments were invalid: parameter "table" is required`,
+				},
+			},
+		},
+		{
+			name: "prompts/get invalid name",
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "prompts-get-invalid",
+				"method":  "prompts/get",
+				"params":  map[string]any{"name": "foo"},
+			},
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "prompts-get-invalid",
+				"error": map[string]any{
+					"code":    -32602.0,
+					"message": `invalid prompt name: prompt with name "foo" does not exist`,
+				},
+			},
+		},
+	}
+	sessionId := initializeSession(t, ts)
+	for _, protocol := range []string{protocolVersion20241105, protocolVersion20250326} {
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("%s %s", protocol, tc.name), func(t *testing.T) {
+				header := map[string]string{}
+				if protocol == protocolVersion20250326 {
+					header["Mcp-Session-Id"] = sessionId
+				}
+				reqMarshal, err := json.Marshal(tc.body)
+				if err != nil {
+					t.Fatalf("unexpected error during marshaling of body")
+				}
+				_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				var got map[string]any
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("unexpected error unmarshalling body: %s", err)
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("unexpected response: got %+v, want %+v", got, tc.want)
+				}
+			})
+		}
+	}
+}
+
+func TestMcpToolsListPagination(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2, tool3}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, withMcpPageSize(2))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	listTools := func(t *testing.T, cursor string) map[string]any {
+		req := map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "tools-list",
+			"method":  "tools/list",
+		}
+		if cursor != "" {
+			req["params"] = map[string]any{"cursor": cursor}
+		}
+		reqMarshal, err := json.Marshal(req)
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling body: %s", err)
+		}
+		return got
+	}
+
+	t.Run("pages through all tools", func(t *testing.T) {
+		var pages [][]string
+		cursor := ""
+		for i := 0; i < len(mockTools); i++ {
+			res := listTools(t, cursor)
+			result, ok := res["result"].(map[string]any)
+			if !ok {
+				t.Fatalf("unexpected response: %+v", res)
+			}
+			var names []string
+			for _, tool := range result["tools"].([]any) {
+				names = append(names, tool.(map[string]any)["name"].(string))
+			}
+			pages = append(pages, names)
+			next, ok := result["nextCursor"].(string)
+			if !ok {
+				break
+			}
+			cursor = next
+		}
+		want := [][]string{{tool1.Name, tool2.Name}, {tool3.Name}}
+		if !reflect.DeepEqual(pages, want) {
+			t.Fatalf("unexpected pages: got %v, want %v", pages, want)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		res := listTools(t, "foo")
+		want := map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "tools-list",
+			"error": map[string]any{
+				"code":    -32602.0,
+				"message": `invalid cursor: "foo"`,
+			},
+		}
+		if !reflect.DeepEqual(res, want) {
+			t.Fatalf("unexpected response: got %+v, want %+v", res, want)
+		}
+	})
+}
+
+func TestMcpBatch(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2, tool3}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name       string
+		body       []any
+		wantStatus int
+		want       []any
+	}{
+		{
+			name: "tool calls",
+			body: []any{
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"id":      "batch-call1",
+					"method":  "tools/call",
+					"params":  map[string]any{"name": tool1.Name},
+				},
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"id":      "batch-call2",
+					"method":  "tools/call",
+					"params":  map[string]any{"name": tool2.Name, "arguments": map[string]any{"param1": 1, "param2": 2}},
+				},
+			},
+			wantStatus: http.StatusOK,
+			want: []any{
+				map[string]any{
+					"jsonrpc": "2.0",
+					"id":      "batch-call1",
+					"result": map[string]any{
+						"content": []any{map[string]any{"type": "text", "text": `"no_params"`}},
+					},
+				},
+				map[string]any{
+					"jsonrpc": "2.0",
+					"id":      "batch-call2",
+					"result": map[string]any{
+						"content": []any{map[string]any{"type": "text", "text": `"some_params"`}},
+					},
+				},
+			},
+		},
+		{
+			name: "requests mixed with notifications and invalid messages",
+			body: []any{
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"method":  "notifications/initialized",
+				},
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"id":      "batch-invalid-method",
+					"method":  "foo",
+				},
+				1,
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"id":      "batch-initialize",
+					"method":  "initialize",
+				},
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"id":      "batch-call",
+					"method":  "tools/call",
+					"params":  map[string]any{"name": tool1.Name},
+				},
+			},
+			wantStatus: http.StatusOK,
+			want: []any{
+				map[string]any{
+					"jsonrpc": "2.0",
+					"id":      "batch-invalid-method",
+					"error": map[string]any{
+						"code":    -32601.0,
+						"message": "invalid method foo",
+					},
+				},
+				map[string]any{
+					"jsonrpc": "2.0",
+					"id":      nil,
+					"error": map[string]any{
+						"code":    -32600.0,
+						"message": "invalid message in batch: json: cannot unmarshal number into Go value of type jsonrpc.BaseMessage",
+					},
+				},
+				map[string]any{
+					"jsonrpc": "2.0",
+					"id":      "batch-initialize",
+					"error": map[string]any{
+						"code":    -32600.0,
+						"message": "initialize request must not be part of a batch",
+					},
+				},
+				map[string]any{
+					"jsonrpc": "2.0",
+					"id":      "batch-call",
+					"result": map[string]any{
+						"content": []any{map[string]any{"type": "text", "text": `"no_params"`}},
+					},
+				},
+			},
+		},
+		{
+			name: "only notifications",
+			body: []any{
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"method":  "notifications/initialized",
+				},
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"method":  "notifications/cancelled",
+				},
+			},
+			wantStatus: http.StatusAccepted,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			resp, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.want == nil {
+				return
+			}
+			var got []any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected response: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// parseSseEvents returns the fields of each event in a SSE response body.
+func parseSseEvents(body []byte) []map[string]string {
+	var events []map[string]string
+	for _, block := range strings.Split(string(body), "\n\n") {
+		event := make(map[string]string)
+		for _, line := range strings.Split(block, "\n") {
+			if k, v, ok := strings.Cut(line, ": "); ok && k != "" {
+				event[k] = v
+			}
+		}
+		if len(event) > 0 {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestMcpStreamableHttp(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2, tool3}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool1.Name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	header := map[string]string{
+		"Accept":         "application/json, text/event-stream",
+		"Mcp-Session-Id": initializeSession(t, ts),
+	}
+	want := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "tools-call",
+		"result": map[string]any{
+			"content": []any{map[string]any{"type": "text", "text": `"no_params"`}},
+		},
+	}
+
+	// POST answered with a stream
+	resp, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("unexpected content-type header: want %s, got %s", "text/event-stream", contentType)
+	}
+	events := parseSseEvents(body)
+	if len(events) != 1 {
+		t.Fatalf("unexpected number of events: got %d, want 1", len(events))
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(events[0]["data"]), &got); err != nil {
+		t.Fatalf("unexpected error unmarshalling event data: %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected response: got %+v, want %+v", got, want)
+	}
+	eventId := events[0]["id"]
+	if eventId == "" {
+		t.Fatalf("event is missing an id")
+	}
+
+	t.Run("resume stream", func(t *testing.T) {
+		streamId, _, _ := strings.Cut(eventId, ":")
+		resp, body, err := runRequest(ts, http.MethodGet, "/", nil, map[string]string{
+			"Accept":         "text/event-stream",
+			"Mcp-Session-Id": header["Mcp-Session-Id"],
+			"Last-Event-ID":  streamId + ":0",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %s", resp.Status)
+		}
+		events := parseSseEvents(body)
+		if len(events) != 1 || events[0]["id"] != eventId {
+			t.Fatalf("unexpected replayed events: %+v", events)
+		}
+	})
+
+	t.Run("resume stream after last event", func(t *testing.T) {
+		_, body, err := runRequest(ts, http.MethodGet, "/", nil, map[string]string{
+			"Accept":         "text/event-stream",
+			"Mcp-Session-Id": header["Mcp-Session-Id"],
+			"Last-Event-ID":  eventId,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if events := parseSseEvents(body); len(events) != 0 {
+			t.Fatalf("unexpected replayed events: %+v", events)
+		}
+	})
+
+	rejectTcs := []struct {
+		name       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{
+			name: "resume unknown stream",
+			header: map[string]string{
+				"Mcp-Session-Id": header["Mcp-Session-Id"],
+				"Last-Event-ID":  "foo:1",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "resume stream of another session",
+			header: map[string]string{
+				"Mcp-Session-Id": initializeSession(t, ts),
+				"Last-Event-ID":  eventId,
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "resume stream without session",
+			header:     map[string]string{"Last-Event-ID": eventId},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resume stream of unknown session",
+			header: map[string]string{
+				"Mcp-Session-Id": "foo",
+				"Last-Event-ID":  eventId,
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "standalone stream",
+			header:     map[string]string{"Mcp-Session-Id": header["Mcp-Session-Id"]},
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range rejectTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.header["Accept"] = "text/event-stream"
+			resp, _, err := runRequest(ts, http.MethodGet, "/", nil, tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %s, want %d", resp.Status, tc.wantStatus)
+			}
+		})
+	}
+}
+
+// blockingTool reports progress and blocks until its invocation is cancelled
+type blockingTool struct {
+	MockTool
+	started chan struct{}
+}
+
+func (t blockingTool) Invoke(ctx context.Context, _ tools.ParamValues) ([]any, error) {
+	util.ReportProgress(ctx, 1, 0, "started")
+	close(t.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestMcpProgressAndCancellation(t *testing.T) {
+	tool := blockingTool{MockTool: MockTool{Name: "blocking"}, started: make(chan struct{})}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset})
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	sessionId := initializeSession(t, ts)
+	header := map[string]string{
+		"Accept":         "application/json, text/event-stream",
+		"Mcp-Session-Id": sessionId,
+	}
+
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":  tool.Name,
+			"_meta": map[string]any{"progressToken": "progress-token"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	type result struct {
+		body []byte
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+		results <- result{body, err}
+	}()
+
+	select {
+	case <-tool.started:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("tool was not invoked")
+	}
+
+	cancelMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"method":  "notifications/cancelled",
+		"params":  map[string]any{"requestId": "tools-call", "reason": "user aborted"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(cancelMarshal), map[string]string{"Mcp-Session-Id": sessionId})
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status: %s", resp.Status)
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("tool call was not cancelled")
+	}
+	if res.err != nil {
+		t.Fatalf("unexpected error during request: %s", res.err)
+	}
+
+	// the stream only has the progress notification, since cancelled requests
+	// don't have a response
+	events := parseSseEvents(res.body)
+	if len(events) != 1 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(events[0]["data"]), &got); err != nil {
+		t.Fatalf("unexpected error unmarshalling event data: %s", err)
+	}
+	want := map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "notifications/progress",
+		"params": map[string]any{
+			"progressToken": "progress-token",
+			"progress":      1.0,
+			"message":       "started",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected notification: got %+v, want %+v", got, want)
+	}
+}
+
+func TestMcpCancellationWithoutSession(t *testing.T) {
+	tool := blockingTool{MockTool: MockTool{Name: "blocking"}, started: make(chan struct{})}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset})
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool.Name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/", bytes.NewBuffer(reqMarshal))
+	if err != nil {
+		t.Fatalf("unable to create request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	results := make(chan error, 1)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		results <- err
+	}()
+
+	select {
+	case <-tool.started:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("tool was not invoked")
+	}
+
+	// another client without a session can't cancel the request
+	cancelMarshal, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"method":  "notifications/cancelled",
+		"params":  map[string]any{"requestId": 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(cancelMarshal), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unexpected status: %s", resp.Status)
+	}
+
+	select {
+	case <-results:
+		t.Fatalf("tool call was cancelled by another client")
+	case <-time.After(200 * time.Millisecond):
+	}
+	cancel()
+	<-results
+}
+
+// titledTool is a tool with a title, a result schema and annotations
+type titledTool struct {
+	MockTool
+}
+
+func (t titledTool) McpManifest() tools.McpManifest {
+	m := t.MockTool.McpManifest()
+	m.Title = "Titled Tool"
+	m.OutputSchema = tools.McpOutputSchema(map[string]any{"type": "array", "items": map[string]any{"type": "string"}})
+	m.Annotations = tools.NewReadOnlyAnnotations()
+	return m
+}
+
+func TestMcpV20250618(t *testing.T) {
+	tool := titledTool{MockTool: MockTool{Name: "titled", Description: "some description"}}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset})
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	sessionId := runInitializeLifecycle(t, ts, protocolVersion20250618, map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "mcp-initialize",
+		"result": map[string]any{
+			"protocolVersion": "2025-06-18",
+			"capabilities": map[string]any{
+				"prompts":   map[string]any{"listChanged": false},
+				"resources": map[string]any{"listChanged": false},
+				"tools":     map[string]any{"listChanged": false},
+			},
+			"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
+		},
+	}, true)
+	oldSessionId := initializeSession(t, ts)
+
+	outputSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"result": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+		"required": []any{"result"},
+	}
+	inputSchema := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{},
+		"required":   []any{},
+	}
+
+	testCases := []struct {
+		name       string
+		header     map[string]string
+		body       any
+		wantStatus int
+		want       any
+	}{
+		{
+			name: "tools/list includes title and output schema",
+			header: map[string]string{
+				"Mcp-Session-Id":       sessionId,
+				"MCP-Protocol-Version": protocolVersion20250618,
+			},
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			},
+			wantStatus: http.StatusOK,
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "tools-list",
+				"result": map[string]any{
+					"tools": []any{
+						map[string]any{
+							"name":         "titled",
+							"title":        "Titled Tool",
+							"description":  "some description",
+							"inputSchema":  inputSchema,
+							"outputSchema": outputSchema,
+							"annotations":  map[string]any{"readOnlyHint": true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "tools/list strips title and output schema for 2025-03-26",
+			header: map[string]string{"Mcp-Session-Id": oldSessionId},
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			},
+			wantStatus: http.StatusOK,
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "tools-list",
+				"result": map[string]any{
+					"tools": []any{
+						map[string]any{
+							"name":        "titled",
+							"description": "some description",
+							"inputSchema": inputSchema,
+							"annotations": map[string]any{"readOnlyHint": true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "tools/call returns structured content",
+			header: map[string]string{"Mcp-Session-Id": sessionId},
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-call",
+				"method":  "tools/call",
+				"params":  map[string]any{"name": tool.Name},
+			},
+			wantStatus: http.StatusOK,
+			want: map[string]any{
+				"jsonrpc": "2.0",
+				"id":      "tools-call",
+				"result": map[string]any{
+					"content":           []any{map[string]any{"type": "text", "text": `"titled"`}},
+					"structuredContent": map[string]any{"result": []any{"titled"}},
+				},
+			},
+		},
+		{
+			name: "batch requests are rejected",
+			header: map[string]string{
+				"Mcp-Session-Id":       sessionId,
+				"MCP-Protocol-Version": protocolVersion20250618,
+			},
+			body: []any{
+				map[string]any{
+					"jsonrpc": jsonrpcVersion,
+					"id":      "tools-list",
+					"method":  "tools/list",
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "unsupported protocol version header",
+			header: map[string]string{
+				"Mcp-Session-Id":       sessionId,
+				"MCP-Protocol-Version": "2020-01-01",
+			},
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "protocol version header does not match the session",
+			header: map[string]string{
+				"Mcp-Session-Id":       sessionId,
+				"MCP-Protocol-Version": protocolVersion20250326,
+			},
+			body: map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			resp, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.want == nil {
+				return
+			}
+			var got any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected response: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// authTool requires the `my-auth` auth service, and returns the `sub` claim
+// of its auth-bound parameter
+type authTool struct {
+	MockTool
+}
+
+func (t authTool) Invoke(_ context.Context, params tools.ParamValues) ([]any, error) {
+	return params.AsSlice(), nil
+}
+
+func (t authTool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized([]string{"my-auth"}, verifiedAuthServices)
+}
+
+func TestMcpAuth(t *testing.T) {
+	tool := authTool{MockTool: MockTool{
+		Name: "auth_required",
+		Params: tools.Parameters{
+			tools.NewStringParameterWithAuth("user", "the user", []tools.ParamAuthService{{Name: "my-auth", Field: "sub"}}),
+		},
+	}}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	authServices := map[string]auth.AuthService{"my-auth": MockTokenAuthService{MockAuthService{Name: "my-auth"}}}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset}, withAuthServices(authServices))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	initialize := func(header map[string]string) string {
+		reqMarshal, err := json.Marshal(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "mcp-initialize",
+			"method":  "initialize",
+			"params":  map[string]any{"protocolVersion": protocolVersion20250326},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		return resp.Header.Get("Mcp-Session-Id")
+	}
+	authSessionId := initialize(map[string]string{"my-auth_token": "alice"})
+	sessionId := initialize(nil)
+
+	authorized := func(user string) map[string]any {
+		return map[string]any{
+			"jsonrpc": "2.0",
+			"id":      "tools-call",
+			"result": map[string]any{
+				"content": []any{map[string]any{"type": "text", "text": fmt.Sprintf("%q", user)}},
+			},
+		}
+	}
+	unauthorized := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "tools-call",
+		"error": map[string]any{
+			"code":    -32602.0,
+			"message": `provided parameters were invalid: error parsing authenticated parameter "user": missing or invalid authentication header`,
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		header map[string]string
+		want   map[string]any
+	}{
+		{
+			name:   "claims from the initialize request",
+			header: map[string]string{"Mcp-Session-Id": authSessionId},
+			want:   authorized("alice"),
+		},
+		{
+			name:   "claims from the request take precedence",
+			header: map[string]string{"Mcp-Session-Id": authSessionId, "my-auth_token": "bob"},
+			want:   authorized("bob"),
+		},
+		{
+			name:   "claims from the request",
+			header: map[string]string{"Mcp-Session-Id": sessionId, "my-auth_token": "bob"},
+			want:   authorized("bob"),
+		},
+		{
+			name:   "no claims",
+			header: map[string]string{"Mcp-Session-Id": sessionId},
+			want:   unauthorized,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-call",
+				"method":  "tools/call",
+				"params":  map[string]any{"name": tool.Name},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("unexpected error unmarshalling body: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected response: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMcpSessionHmacClaims(t *testing.T) {
+	tool := authTool{MockTool: MockTool{
+		Name: "auth_required",
+		Params: tools.Parameters{
+			tools.NewStringParameterWithAuth("user", "the user", []tools.ParamAuthService{{Name: "my-auth", Field: "sub"}}),
+		},
+	}}
+	toolsMap := map[string]tools.Tool{tool.Name: tool}
+	toolset, err := tools.ToolsetConfig{Name: "", ToolNames: []string{tool.Name}}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	hmacService, err := hmac.Config{Name: "my-auth", Kind: hmac.AuthServiceKind, Keys: []hmac.Key{{Id: "alice", Secret: "secret"}}}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+	authServices := map[string]auth.AuthService{"my-auth": hmacService}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, map[string]tools.Toolset{"": toolset}, withAuthServices(authServices))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	nonce := 0
+	signedHeader := func(body []byte) map[string]string {
+		nonce++
+		timestamp := fmt.Sprint(time.Now().Unix())
+		signature := hmac.Sign("secret", http.MethodPost, "/", timestamp, fmt.Sprint(nonce), body)
+		return map[string]string{
+			"my-auth_key_id":    "alice",
+			"my-auth_timestamp": timestamp,
+			"my-auth_nonce":     fmt.Sprint(nonce),
+			"my-auth_signature": fmt.Sprintf("%x", signature),
+		}
+	}
+
+	initialize, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "mcp-initialize",
+		"method":  "initialize",
+		"params":  map[string]any{"protocolVersion": protocolVersion20250326},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+	resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(initialize), signedHeader(initialize))
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	sessionId := resp.Header.Get("Mcp-Session-Id")
+
+	toolsCall, err := json.Marshal(map[string]any{
+		"jsonrpc": jsonrpcVersion,
+		"id":      "tools-call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": tool.Name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during marshaling of body")
+	}
+
+	testCases := []struct {
+		name   string
+		header map[string]string
+		want   string
+	}{
+		{
+			name:   "unsigned request of a signed session",
+			header: map[string]string{"Mcp-Session-Id": sessionId},
+			want:   "missing or invalid authentication header",
+		},
+		{
+			name:   "signed request",
+			header: signedHeader(toolsCall),
+			want:   `\"alice\"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.header["Mcp-Session-Id"] = sessionId
+			_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(toolsCall), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if !strings.Contains(string(body), tc.want) {
+				t.Fatalf("unexpected response: got %s, want it to contain %s", body, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeClaims(t *testing.T) {
+	now := time.Now()
+	sessionClaims := map[string]map[string]any{
+		"expired":   {"sub": "alice", "exp": float64(now.Add(-time.Minute).Unix())},
+		"valid":     {"sub": "alice", "exp": float64(now.Add(time.Hour).Unix())},
+		"no-expiry": {"sub": "alice"},
+	}
+	sessionTokens := map[string]string{"expired": "a", "valid": "b", "no-expiry": "c"}
+	expiries := claimsExpiries(sessionClaims)
+
+	gotClaims := mergeClaims(sessionClaims, expiries, map[string]map[string]any{"request": {"sub": "bob"}})
+	wantClaims := map[string]map[string]any{
+		"valid":   sessionClaims["valid"],
+		"request": {"sub": "bob"},
+	}
+	if !reflect.DeepEqual(gotClaims, wantClaims) {
+		t.Fatalf("unexpected claims: got %+v, want %+v", gotClaims, wantClaims)
+	}
+
+	gotTokens := mergeClaims(sessionTokens, expiries, map[string]string{})
+	wantTokens := map[string]string{"valid": "b"}
+	if !reflect.DeepEqual(gotTokens, wantTokens) {
+		t.Fatalf("unexpected tokens: got %+v, want %+v", gotTokens, wantTokens)
+	}
+}
+
+func TestMcpPolicy(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	authServices := map[string]auth.AuthService{"my-auth": MockAuthService{Name: "my-auth"}}
+	toolPolicies := map[string]*tools.Policy{
+		tool2.Name: {AllOf: []tools.PolicyRule{{Claim: "sub", Equals: "alice"}}},
+	}
+	toolsetPolicies := map[string]*tools.Policy{
+		"tool1_only": {AllOf: []tools.PolicyRule{{Claim: "sub", In: []any{"alice", "bob"}}}},
+	}
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets,
+		withAuthServices(authServices),
+		withAuthorizer(t, toolPolicies, toolsetPolicies),
+	)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	sessionId := func(toolset string) string {
+		reqMarshal, err := json.Marshal(map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "mcp-initialize",
+			"method":  "initialize",
+			"params":  map[string]any{"protocolVersion": protocolVersion20250326},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		resp, _, err := runRequest(ts, http.MethodPost, "/"+toolset, bytes.NewBuffer(reqMarshal), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		return resp.Header.Get("Mcp-Session-Id")
+	}
+	defaultSession := sessionId("")
+	toolsetSession := sessionId("tool1_only")
+
+	send := func(toolset string, header map[string]string, body map[string]any) map[string]any {
+		reqMarshal, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("unexpected error during marshaling of body")
+		}
+		_, respBody, err := runRequest(ts, http.MethodPost, "/"+toolset, bytes.NewBuffer(reqMarshal), header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(respBody, &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling body: %s", err)
+		}
+		return got
+	}
+
+	testCases := []struct {
+		name        string
+		toolset     string
+		session     string
+		token       string
+		wantTools   []string
+		wantCallErr bool
+	}{
+		{
+			name:        "no claims",
+			session:     defaultSession,
+			wantTools:   []string{},
+			wantCallErr: true,
+		},
+		{
+			name:        "claims not allowed by the tool policy",
+			session:     defaultSession,
+			token:       "bob",
+			wantTools:   []string{tool1.Name},
+			wantCallErr: true,
+		},
+		{
+			name:      "claims allowed by the tool policy",
+			session:   defaultSession,
+			token:     "alice",
+			wantTools: []string{tool1.Name, tool2.Name},
+		},
+		{
+			name:        "claims not allowed by the toolset policy",
+			toolset:     "tool1_only",
+			session:     toolsetSession,
+			token:       "carol",
+			wantTools:   []string{},
+			wantCallErr: true,
+		},
+		{
+			name:      "claims allowed by the toolset policy",
+			toolset:   "tool1_only",
+			session:   toolsetSession,
+			token:     "bob",
+			wantTools: []string{tool1.Name},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := map[string]string{"Mcp-Session-Id": tc.session}
+			if tc.token != "" {
+				header["my-auth_token"] = tc.token
+			}
+
+			got := send(tc.toolset, header, map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			})
+			result, ok := got["result"].(map[string]any)
+			if !ok {
+				t.Fatalf("unexpected response: %v", got)
+			}
+			gotTools := []string{}
+			for _, tool := range result["tools"].([]any) {
+				gotTools = append(gotTools, tool.(map[string]any)["name"].(string))
+			}
+			if !reflect.DeepEqual(gotTools, tc.wantTools) {
+				t.Fatalf("unexpected tools: got %v, want %v", gotTools, tc.wantTools)
+			}
+
+			// call the tool with a policy, or any tool of the toolset with
+			// a policy
+			callTool := tool1.Name
+			if tc.toolset == "" {
+				callTool = tool2.Name
+			}
+			got = send(tc.toolset, header, map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-call",
+				"method":  "tools/call",
+				"params":  map[string]any{"name": callTool, "arguments": map[string]any{"param1": 1, "param2": 2}},
+			})
+			_, gotErr := got["error"]
+			if gotErr != tc.wantCallErr {
+				t.Fatalf("unexpected tools/call response: %v", got)
+			}
+		})
+	}
+
+	t.Run("toolset policy applies through the default toolset", func(t *testing.T) {
+		header := map[string]string{"Mcp-Session-Id": defaultSession, "my-auth_token": "carol"}
+		got := send("", header, map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "tools-call",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool1.Name},
+		})
+		if _, ok := got["error"]; !ok {
+			t.Fatalf("unexpected tools/call response: %v", got)
+		}
+	})
+	t.Run("tool outside of the toolset", func(t *testing.T) {
+		header := map[string]string{"Mcp-Session-Id": toolsetSession, "my-auth_token": "alice"}
+		got := send("tool1_only", header, map[string]any{
+			"jsonrpc": jsonrpcVersion,
+			"id":      "tools-call",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool2.Name, "arguments": map[string]any{"param1": 1, "param2": 2}},
+		})
+		gotErr, ok := got["error"].(map[string]any)
+		if !ok || gotErr["code"] != float64(jsonrpc.INVALID_PARAMS) {
+			t.Fatalf("unexpected tools/call response: %v", got)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+)
+
+// protectedResourceMetadataHandler serves the OAuth 2.0 Protected Resource
+// Metadata of the MCP endpoint.
+func protectedResourceMetadataHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, s.protectedResource.ResourceMetadata())
+}
+
+// resourceMetadataURL returns the absolute URL of the protected resource
+// metadata, which is sent to clients in the `WWW-Authenticate` header.
+func resourceMetadataURL(pr auth.ProtectedResource) string {
+	resource, err := url.Parse(pr.ResourceMetadata().Resource)
+	if err != nil {
+		return pr.ResourceMetadataPath()
+	}
+	return (&url.URL{Scheme: resource.Scheme, Host: resource.Host, Path: pr.ResourceMetadataPath()}).String()
+}
+
+// protectedResourceMiddleware rejects MCP requests without a valid bearer
+// token, as described by the MCP authorization specification.
+func protectedResourceMiddleware(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			claims, err := s.protectedResource.GetClaimsFromHeader(ctx, r.Header)
+			if err == nil && claims != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			params := []string{fmt.Sprintf("resource_metadata=%q", resourceMetadataURL(s.protectedResource))}
+			status := http.StatusUnauthorized
+			switch {
+			case errors.Is(err, auth.ErrInsufficientScope):
+				status = http.StatusForbidden
+				params = append(params, `error="insufficient_scope"`)
+				if scopes := s.protectedResource.ResourceMetadata().ScopesSupported; len(scopes) > 0 {
+					params = append(params, fmt.Sprintf("scope=%q", strings.Join(scopes, " ")))
+				}
+			case err != nil:
+				params = append(params, `error="invalid_token"`)
+			default:
+				err = fmt.Errorf("missing bearer token")
+			}
+			s.logger.DebugContext(ctx, err.Error())
+			w.Header().Set("WWW-Authenticate", "Bearer "+strings.Join(params, ", "))
+			_ = render.Render(w, r, newErrResponse(err, status))
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/oauthresource"
+)
+
+// newProtectedResource returns a protected resource that verifies tokens with
+// a local JWKS file, and a function that signs access tokens.
+func newProtectedResource(t *testing.T) (auth.ProtectedResource, func(claims map[string]any) string) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	key := jose.JSONWebKey{Key: k, KeyID: "key-1", Algorithm: string(jose.RS256), Use: "sig"}
+	b, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key.Public()}})
+	if err != nil {
+		t.Fatalf("unable to marshal key set: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatalf("unable to write key set: %s", err)
+	}
+
+	a, err := oauthresource.Config{
+		Name:                "my-oauth",
+		Kind:                oauthresource.AuthServiceKind,
+		Resource:            "https://toolbox.example.com/mcp",
+		AuthorizationServer: "https://auth.example.com",
+		JwksFile:            path,
+		Scopes:              []string{"toolbox"},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+	if err != nil {
+		t.Fatalf("unable to create signer: %s", err)
+	}
+	sign := func(claims map[string]any) string {
+		token, err := jwt.Signed(signer).Claims(claims).Serialize()
+		if err != nil {
+			t.Fatalf("unable to sign token: %s", err)
+		}
+		return token
+	}
+	return a.(auth.ProtectedResource), sign
+}
+
+func TestProtectedResource(t *testing.T) {
+	pr, sign := newProtectedResource(t)
+	mockTools := []MockTool{tool1, tool2, tool3}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets, withProtectedResource(pr))
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	claims := func(scope string) map[string]any {
+		return map[string]any{
+			"iss":   "https://auth.example.com",
+			"aud":   "https://toolbox.example.com/mcp",
+			"sub":   "alice",
+			"scope": scope,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	metadataURL := `resource_metadata="https://toolbox.example.com/.well-known/oauth-protected-resource/mcp"`
+
+	testCases := []struct {
+		name                string
+		header              map[string]string
+		wantStatus          int
+		wantWwwAuthenticate string
+	}{
+		{
+			name:                "missing token",
+			wantStatus:          http.StatusUnauthorized,
+			wantWwwAuthenticate: "Bearer " + metadataURL,
+		},
+		{
+			name:                "invalid token",
+			header:              map[string]string{"Authorization": "Bearer foo"},
+			wantStatus:          http.StatusUnauthorized,
+			wantWwwAuthenticate: "Bearer " + metadataURL + `, error="invalid_token"`,
+		},
+		{
+			name:                "insufficient scope",
+			header:              map[string]string{"Authorization": "Bearer " + sign(claims("other"))},
+			wantStatus:          http.StatusForbidden,
+			wantWwwAuthenticate: "Bearer " + metadataURL + `, error="insufficient_scope", scope="toolbox"`,
+		},
+		{
+			name:       "valid token",
+			header:     map[string]string{"Authorization": "Bearer " + sign(claims("openid toolbox"))},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqMarshal, err := json.Marshal(map[string]any{
+				"jsonrpc": jsonrpcVersion,
+				"id":      "tools-list",
+				"method":  "tools/list",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error during marshaling of body")
+			}
+			resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBuffer(reqMarshal), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if got := resp.Header.Get("WWW-Authenticate"); got != tc.wantWwwAuthenticate {
+				t.Fatalf("unexpected WWW-Authenticate header: got %s, want %s", got, tc.wantWwwAuthenticate)
+			}
+		})
+	}
+
+	t.Run("resource metadata", func(t *testing.T) {
+		if got := pr.ResourceMetadataPath(); got != "/.well-known/oauth-protected-resource/mcp" {
+			t.Fatalf("unexpected metadata path: %s", got)
+		}
+		w := httptest.NewRecorder()
+		protectedResourceMetadataHandler(&Server{protectedResource: pr}, w, httptest.NewRequest(http.MethodGet, pr.ResourceMetadataPath(), nil))
+		var got map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling body: %s", err)
+		}
+		want := map[string]any{
+			"resource":                 "https://toolbox.example.com/mcp",
+			"authorization_servers":    []any{"https://auth.example.com"},
+			"scopes_supported":         []any{"toolbox"},
+			"bearer_methods_supported": []any{"header"},
+			"resource_name":            "my-oauth",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected metadata: got %+v, want %+v", got, want)
+		}
+	})
+}
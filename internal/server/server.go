@@ -20,6 +20,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -28,6 +29,8 @@ import (
 	"github.com/go-chi/httplog/v2"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	"github.com/googleapis/genai-toolbox/internal/resources"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
@@ -44,11 +47,21 @@ type Server struct {
 	logger          log.Logger
 	instrumentation *Instrumentation
 	sseManager      *sseManager
+	streamManager   *streamManager
+	sessionManager  *sessionManager
+	requests        requestTracker
+	mcpPageSize     int
+	// protectedResource protects the MCP endpoint as an OAuth 2.0 resource
+	// server, if configured
+	protectedResource auth.ProtectedResource
 
 	sources      map[string]sources.Source
 	authServices map[string]auth.AuthService
 	tools        map[string]tools.Tool
 	toolsets     map[string]tools.Toolset
+	authorizer   *tools.Authorizer
+	resources    map[string]resources.Resource
+	prompts      map[string]prompts.Prompt
 }
 
 // NewServer returns a Server object based on provided Config.
@@ -145,6 +158,19 @@ func NewServer(ctx context.Context, cfg ServerConfig, l log.Logger) (*Server, er
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d authServices.", len(authServicesMap)))
 
+	// at most one auth service can protect the MCP endpoint
+	var protectedResource auth.ProtectedResource
+	for name, a := range authServicesMap {
+		pr, ok := a.(auth.ProtectedResource)
+		if !ok {
+			continue
+		}
+		if protectedResource != nil {
+			return nil, fmt.Errorf("only one protected resource auth service can be configured, found %q and %q", protectedResource.GetName(), name)
+		}
+		protectedResource = pr
+	}
+
 	// initialize and validate the tools from configs
 	toolsMap := make(map[string]tools.Tool)
 	for name, tc := range cfg.ToolConfigs {
@@ -174,6 +200,8 @@ func NewServer(ctx context.Context, cfg ServerConfig, l log.Logger) (*Server, er
 	for name := range toolsMap {
 		allToolNames = append(allToolNames, name)
 	}
+	// sort the tools so that the default toolset is listed in a stable order
+	sort.Strings(allToolNames)
 	if cfg.ToolsetConfigs == nil {
 		cfg.ToolsetConfigs = make(ToolsetConfigs)
 	}
@@ -202,10 +230,73 @@ func NewServer(ctx context.Context, cfg ServerConfig, l log.Logger) (*Server, er
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d toolsets.", len(toolsetsMap)))
 
+	// the policies of tools and toolsets are evaluated by a single authorizer
+	toolPolicies := make(map[string]*tools.Policy)
+	for name, tc := range cfg.ToolConfigs {
+		toolPolicies[name] = tc.ToolPolicy()
+	}
+	authorizer, err := tools.NewAuthorizer(toolPolicies, cfg.ToolsetConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	// initialize and validate the resources from configs
+	resourcesMap := make(map[string]resources.Resource)
+	for name, rc := range cfg.ResourceConfigs {
+		r, err := func() (resources.Resource, error) {
+			_, span := instrumentation.Tracer.Start(
+				ctx,
+				"toolbox/server/resource/init",
+				trace.WithAttributes(attribute.String("resource_name", name)),
+			)
+			defer span.End()
+			// resources are not tied to an authenticated request, so tools
+			// with a policy can not back a resource
+			if tc, ok := cfg.ToolConfigs[rc.Tool]; ok && tc.ToolPolicy() != nil {
+				return resources.Resource{}, fmt.Errorf("unable to initialize resource %q: tool %q has a policy", name, rc.Tool)
+			}
+			r, err := rc.Initialize(toolsMap)
+			if err != nil {
+				return resources.Resource{}, fmt.Errorf("unable to initialize resource %q: %w", name, err)
+			}
+			return r, nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		resourcesMap[name] = r
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d resources.", len(resourcesMap)))
+
+	// initialize and validate the prompts from configs
+	promptsMap := make(map[string]prompts.Prompt)
+	for name, pc := range cfg.PromptConfigs {
+		p, err := func() (prompts.Prompt, error) {
+			_, span := instrumentation.Tracer.Start(
+				ctx,
+				"toolbox/server/prompt/init",
+				trace.WithAttributes(attribute.String("prompt_name", name)),
+			)
+			defer span.End()
+			p, err := pc.Initialize()
+			if err != nil {
+				return prompts.Prompt{}, fmt.Errorf("unable to initialize prompt %q: %w", name, err)
+			}
+			return p, nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		promptsMap[name] = p
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d prompts.", len(promptsMap)))
+
 	addr := net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))
 	srv := &http.Server{Addr: addr, Handler: r}
 
 	sseManager := newSseManager(ctx)
+	streamManager := newStreamManager(ctx)
+	sessionManager := newSessionManager(ctx, cfg.McpSessionTTL)
 
 	s := &Server{
 		version:         cfg.Version,
@@ -214,11 +305,19 @@ func NewServer(ctx context.Context, cfg ServerConfig, l log.Logger) (*Server, er
 		logger:          l,
 		instrumentation: instrumentation,
 		sseManager:      sseManager,
+		streamManager:   streamManager,
+		sessionManager:  sessionManager,
+		mcpPageSize:     cfg.McpPageSize,
+
+		protectedResource: protectedResource,
 
 		sources:      sourcesMap,
 		authServices: authServicesMap,
 		tools:        toolsMap,
 		toolsets:     toolsetsMap,
+		authorizer:   authorizer,
+		resources:    resourcesMap,
+		prompts:      promptsMap,
 	}
 	// control plane
 	apiR, err := apiRouter(s)
@@ -231,6 +330,9 @@ func NewServer(ctx context.Context, cfg ServerConfig, l log.Logger) (*Server, er
 		return nil, err
 	}
 	r.Mount("/mcp", mcpR)
+	if protectedResource != nil {
+		r.Get(protectedResource.ResourceMetadataPath(), func(w http.ResponseWriter, r *http.Request) { protectedResourceMetadataHandler(s, w, r) })
+	}
 	// default endpoint for validating server is running
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("🧰 Hello, World! 🧰"))
@@ -49,16 +49,17 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 
 type Config struct {
 	// Cloud SQL MSSQL configs
-	Name      string         `yaml:"name" validate:"required"`
-	Kind      string         `yaml:"kind" validate:"required"`
-	Project   string         `yaml:"project" validate:"required"`
-	Region    string         `yaml:"region" validate:"required"`
-	Instance  string         `yaml:"instance" validate:"required"`
-	IPAddress string         `yaml:"ipAddress" validate:"required"`
-	IPType    sources.IPType `yaml:"ipType" validate:"required"`
-	User      string         `yaml:"user" validate:"required"`
-	Password  string         `yaml:"password" validate:"required"`
-	Database  string         `yaml:"database" validate:"required"`
+	Name         string               `yaml:"name" validate:"required"`
+	Kind         string               `yaml:"kind" validate:"required"`
+	Project      string               `yaml:"project" validate:"required"`
+	Region       string               `yaml:"region" validate:"required"`
+	Instance     string               `yaml:"instance" validate:"required"`
+	IPAddress    string               `yaml:"ipAddress" validate:"required"`
+	IPType       sources.IPType       `yaml:"ipType" validate:"required"`
+	User         string               `yaml:"user" validate:"required"`
+	Password     string               `yaml:"password" validate:"required"`
+	Database     string               `yaml:"database" validate:"required"`
+	SessionSetup sources.SessionSetup `yaml:"sessionSetup"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -67,6 +68,10 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if err := r.SessionSetup.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Initializes a Cloud SQL MSSQL source
 	db, err := initCloudSQLMssqlConnection(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPAddress, r.IPType.String(), r.User, r.Password, r.Database)
 	if err != nil {
@@ -80,9 +85,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name: r.Name,
-		Kind: SourceKind,
-		Db:   db,
+		Name:         r.Name,
+		Kind:         SourceKind,
+		Db:           db,
+		SessionSetup: r.SessionSetup,
 	}
 	return s, nil
 }
@@ -91,9 +97,10 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	// Cloud SQL MSSQL struct with connection pool
-	Name string `yaml:"name"`
-	Kind string `yaml:"kind"`
-	Db   *sql.DB
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	Db           *sql.DB
+	SessionSetup sources.SessionSetup
 }
 
 func (s *Source) SourceKind() string {
@@ -106,6 +113,10 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
+func (s *Source) MSSQLSessionSetup() sources.SessionSetup {
+	return s.SessionSetup
+}
+
 func initCloudSQLMssqlConnection(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipAddress, ipType, user, pass, dbname string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
@@ -47,15 +47,16 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 }
 
 type Config struct {
-	Name     string         `yaml:"name" validate:"required"`
-	Kind     string         `yaml:"kind" validate:"required"`
-	Project  string         `yaml:"project" validate:"required"`
-	Region   string         `yaml:"region" validate:"required"`
-	Instance string         `yaml:"instance" validate:"required"`
-	IPType   sources.IPType `yaml:"ipType" validate:"required"`
-	Database string         `yaml:"database" validate:"required"`
-	User     string         `yaml:"user"`
-	Password string         `yaml:"password"`
+	Name         string               `yaml:"name" validate:"required"`
+	Kind         string               `yaml:"kind" validate:"required"`
+	Project      string               `yaml:"project" validate:"required"`
+	Region       string               `yaml:"region" validate:"required"`
+	Instance     string               `yaml:"instance" validate:"required"`
+	IPType       sources.IPType       `yaml:"ipType" validate:"required"`
+	Database     string               `yaml:"database" validate:"required"`
+	User         string               `yaml:"user"`
+	Password     string               `yaml:"password"`
+	SessionSetup sources.SessionSetup `yaml:"sessionSetup"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -63,6 +64,10 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if err := r.SessionSetup.Validate(); err != nil {
+		return nil, err
+	}
+
 	pool, err := initCloudSQLPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
@@ -74,9 +79,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name: r.Name,
-		Kind: SourceKind,
-		Pool: pool,
+		Name:         r.Name,
+		Kind:         SourceKind,
+		Pool:         pool,
+		SessionSetup: r.SessionSetup,
 	}
 	return s, nil
 }
@@ -84,9 +90,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 var _ sources.Source = &Source{}
 
 type Source struct {
-	Name string `yaml:"name"`
-	Kind string `yaml:"kind"`
-	Pool *pgxpool.Pool
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	Pool         *pgxpool.Pool
+	SessionSetup sources.SessionSetup
 }
 
 func (s *Source) SourceKind() string {
@@ -97,6 +104,10 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+func (s *Source) PostgresSessionSetup() sources.SessionSetup {
+	return s.SessionSetup
+}
+
 func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string, bool, error) {
 	useIAM := true
 
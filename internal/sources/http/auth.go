@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/idtoken"
+)
+
+const (
+	AuthTypeClientCredentials = "client-credentials"
+	AuthTypeRefreshToken      = "refresh-token"
+	AuthTypeGoogle            = "google"
+	AuthTypeBasic             = "basic"
+)
+
+// AuthConfig configures the credentials that the source sends with every
+// request.
+type AuthConfig struct {
+	Type string `yaml:"type" validate:"required"`
+	// client-credentials and refresh-token
+	TokenURL       string            `yaml:"tokenUrl"`
+	ClientId       string            `yaml:"clientId"`
+	ClientSecret   string            `yaml:"clientSecret"`
+	Scopes         []string          `yaml:"scopes"`
+	EndpointParams map[string]string `yaml:"endpointParams"`
+	// refresh-token
+	RefreshToken string `yaml:"refreshToken"`
+	// google: an ID token for the audience is sent instead of an access token
+	Audience string `yaml:"audience"`
+	// basic
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Transport returns a RoundTripper that adds the credentials to the requests
+// sent with base. Tokens are fetched with a client that uses base.
+func (cfg AuthConfig) Transport(ctx context.Context, base *http.Client) (http.RoundTripper, error) {
+	if cfg.Type == AuthTypeBasic {
+		if cfg.Username == "" {
+			return nil, fmt.Errorf("%q auth requires a username", cfg.Type)
+		}
+		return &authTransport{base: base.Transport, username: cfg.Username, password: cfg.Password}, nil
+	}
+	ts, err := cfg.tokenSource(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	// ReuseTokenSource caches the token until it expires
+	return &authTransport{base: base.Transport, tokens: oauth2.ReuseTokenSource(nil, ts)}, nil
+}
+
+// tokenSource returns the source of the tokens for the auth type. The context
+// is kept to refresh tokens, so it must not be canceled.
+func (cfg AuthConfig) tokenSource(ctx context.Context, base *http.Client) (oauth2.TokenSource, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, base)
+	switch cfg.Type {
+	case AuthTypeClientCredentials:
+		if err := cfg.validateEndpoint(); err != nil {
+			return nil, err
+		}
+		params := make(url.Values)
+		for k, v := range cfg.EndpointParams {
+			params.Set(k, v)
+		}
+		c := clientcredentials.Config{
+			ClientID:       cfg.ClientId,
+			ClientSecret:   cfg.ClientSecret,
+			TokenURL:       cfg.TokenURL,
+			Scopes:         cfg.Scopes,
+			EndpointParams: params,
+		}
+		return c.TokenSource(ctx), nil
+	case AuthTypeRefreshToken:
+		if err := cfg.validateEndpoint(); err != nil {
+			return nil, err
+		}
+		if cfg.RefreshToken == "" {
+			return nil, fmt.Errorf("%q auth requires a refreshToken", cfg.Type)
+		}
+		c := oauth2.Config{
+			ClientID:     cfg.ClientId,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: cfg.TokenURL},
+			Scopes:       cfg.Scopes,
+		}
+		return c.TokenSource(ctx, &oauth2.Token{RefreshToken: cfg.RefreshToken}), nil
+	case AuthTypeGoogle:
+		if cfg.Audience != "" {
+			ts, err := idtoken.NewTokenSource(ctx, cfg.Audience)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get ID token source from ADC: %w", err)
+			}
+			return ts, nil
+		}
+		scopes := cfg.Scopes
+		if len(scopes) == 0 {
+			scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
+		}
+		ts, err := google.DefaultTokenSource(ctx, scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find default credentials: %w", err)
+		}
+		return ts, nil
+	default:
+		return nil, fmt.Errorf("unknown auth type %q: must be one of %q", cfg.Type, []string{AuthTypeClientCredentials, AuthTypeRefreshToken, AuthTypeGoogle, AuthTypeBasic})
+	}
+}
+
+func (cfg AuthConfig) validateEndpoint() error {
+	if _, err := url.ParseRequestURI(cfg.TokenURL); err != nil {
+		return fmt.Errorf("%q auth requires a valid tokenUrl: %w", cfg.Type, err)
+	}
+	if cfg.ClientId == "" {
+		return fmt.Errorf("%q auth requires a clientId", cfg.Type)
+	}
+	return nil
+}
+
+// authTransport adds credentials to requests that don't already have an
+// `Authorization` header, e.g. a forwarded token.
+type authTransport struct {
+	base   http.RoundTripper
+	tokens oauth2.TokenSource
+	// basic auth is used if there is no token source
+	username string
+	password string
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	if t.tokens == nil {
+		req.SetBasicAuth(t.username, t.password)
+		return t.base.RoundTrip(req)
+	}
+	token, err := t.tokens.Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token for source auth: %w", err)
+	}
+	token.SetAuthHeader(req)
+	return t.base.RoundTrip(req)
+}
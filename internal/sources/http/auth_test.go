@@ -0,0 +1,233 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSourceAuth(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tokenRequests := 0
+	tokenServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		tokenRequests++
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %s", err)
+		}
+		token := ""
+		switch r.PostForm.Get("grant_type") {
+		case "client_credentials":
+			token = "client-token"
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") == "my-refresh-token" {
+				token = "refreshed-token"
+			}
+		}
+		if token == "" {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": token,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	// the API returns the Authorization header it received
+	api := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer api.Close()
+
+	tcs := []struct {
+		desc          string
+		auth          http.AuthConfig
+		header        string
+		want          string
+		tokenRequests int
+	}{
+		{
+			desc: "client credentials",
+			auth: http.AuthConfig{
+				Type:         http.AuthTypeClientCredentials,
+				TokenURL:     tokenServer.URL,
+				ClientId:     "my-client",
+				ClientSecret: "my-secret",
+			},
+			want:          "Bearer client-token",
+			tokenRequests: 1,
+		},
+		{
+			desc: "refresh token",
+			auth: http.AuthConfig{
+				Type:         http.AuthTypeRefreshToken,
+				TokenURL:     tokenServer.URL,
+				ClientId:     "my-client",
+				RefreshToken: "my-refresh-token",
+			},
+			want:          "Bearer refreshed-token",
+			tokenRequests: 1,
+		},
+		{
+			desc: "basic",
+			auth: http.AuthConfig{
+				Type:     http.AuthTypeBasic,
+				Username: "user",
+				Password: "pass",
+			},
+			want: "Basic dXNlcjpwYXNz",
+		},
+		{
+			desc: "existing authorization header",
+			auth: http.AuthConfig{
+				Type:         http.AuthTypeClientCredentials,
+				TokenURL:     tokenServer.URL,
+				ClientId:     "my-client",
+				ClientSecret: "my-secret",
+			},
+			header: "Bearer forwarded-token",
+			want:   "Bearer forwarded-token",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tokenRequests = 0
+			cfg := http.Config{
+				Name:    "my-http-instance",
+				Kind:    http.SourceKind,
+				BaseURL: api.URL,
+				Timeout: "10s",
+				Auth:    &tc.auth,
+			}
+			s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+			if err != nil {
+				t.Fatalf("unable to initialize source: %s", err)
+			}
+			client := s.(*http.Source).Client
+			// the token is cached across requests
+			for i := 0; i < 2; i++ {
+				req, _ := nethttp.NewRequest(nethttp.MethodGet, api.URL, nil)
+				if tc.header != "" {
+					req.Header.Set("Authorization", tc.header)
+				}
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				got, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatalf("unable to read response: %s", err)
+				}
+				if string(got) != tc.want {
+					t.Fatalf("unexpected Authorization header: got %q, want %q", string(got), tc.want)
+				}
+			}
+			if tokenRequests != tc.tokenRequests {
+				t.Fatalf("unexpected number of token requests: got %d, want %d", tokenRequests, tc.tokenRequests)
+			}
+		})
+	}
+}
+
+func TestSourceAuthNotSentToTokenExchange(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var gotAuthorization string
+	sts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		gotAuthorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "exchanged-token",
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		})
+	}))
+	defer sts.Close()
+
+	cfg := http.Config{
+		Name:    "my-http-instance",
+		Kind:    http.SourceKind,
+		BaseURL: "https://api.example.com",
+		Timeout: "10s",
+		Auth:    &http.AuthConfig{Type: http.AuthTypeBasic, Username: "user", Password: "pass"},
+		ForwardAuth: &http.ForwardAuthConfig{
+			AuthService:   "my-oidc",
+			TokenExchange: &http.TokenExchangeConfig{TokenURL: sts.URL},
+		},
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tokenCtx := util.WithAuthTokens(ctx, map[string]string{"my-oidc": "user-token"})
+	if _, err := s.(*http.Source).Forwarder.Token(tokenCtx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotAuthorization != "" {
+		t.Fatalf("the credentials of the source were sent to the token exchange: %q", gotAuthorization)
+	}
+}
+
+func TestSourceAuthInvalid(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		auth http.AuthConfig
+	}{
+		{desc: "unknown type", auth: http.AuthConfig{Type: "digest"}},
+		{desc: "missing token url", auth: http.AuthConfig{Type: http.AuthTypeClientCredentials, ClientId: "my-client"}},
+		{desc: "missing refresh token", auth: http.AuthConfig{Type: http.AuthTypeRefreshToken, TokenURL: "https://example.com/token", ClientId: "my-client"}},
+		{desc: "missing username", auth: http.AuthConfig{Type: http.AuthTypeBasic}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := http.Config{
+				Name:    "my-http-instance",
+				Kind:    http.SourceKind,
+				BaseURL: "http://test_server/",
+				Timeout: "10s",
+				Auth:    &tc.auth,
+			}
+			if _, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer("")); err == nil {
+				t.Fatalf("expected initialization to fail")
+			}
+		})
+	}
+}
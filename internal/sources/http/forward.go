@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	// TokenTypeAccessToken is the RFC 8693 type of OAuth 2.0 access tokens.
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	// TokenTypeIdToken is the RFC 8693 type of OpenID Connect ID tokens.
+	TokenTypeIdToken = "urn:ietf:params:oauth:token-type:id_token"
+
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// exchanged tokens are refreshed this long before they expire
+	expiryDelta = 30 * time.Second
+)
+
+// ForwardAuthConfig configures the token of the caller, as verified by an auth
+// service, to be sent to the upstream API in the `Authorization` header.
+type ForwardAuthConfig struct {
+	// AuthService is the name of the auth service whose token is forwarded.
+	AuthService string `yaml:"authService" validate:"required"`
+	// TokenExchange exchanges the token for a token of the upstream API
+	// before it is forwarded.
+	TokenExchange *TokenExchangeConfig `yaml:"tokenExchange"`
+}
+
+// TokenExchangeConfig configures an OAuth 2.0 Token Exchange (RFC 8693).
+type TokenExchangeConfig struct {
+	TokenURL           string   `yaml:"tokenUrl" validate:"required"`
+	ClientId           string   `yaml:"clientId"`
+	ClientSecret       string   `yaml:"clientSecret"`
+	Audience           string   `yaml:"audience"`
+	Resource           string   `yaml:"resource"`
+	Scopes             []string `yaml:"scopes"`
+	SubjectTokenType   string   `yaml:"subjectTokenType"`
+	RequestedTokenType string   `yaml:"requestedTokenType"`
+}
+
+// Initialize returns a TokenForwarder that uses client for token exchanges.
+func (cfg ForwardAuthConfig) Initialize(client *http.Client) (*TokenForwarder, error) {
+	if cfg.AuthService == "" {
+		return nil, fmt.Errorf("forwardAuth requires an authService")
+	}
+	f := &TokenForwarder{authService: cfg.AuthService}
+	if cfg.TokenExchange == nil {
+		return f, nil
+	}
+	exchange := *cfg.TokenExchange
+	if _, err := url.ParseRequestURI(exchange.TokenURL); err != nil {
+		return nil, fmt.Errorf("failed to parse tokenUrl of tokenExchange: %w", err)
+	}
+	if exchange.SubjectTokenType == "" {
+		exchange.SubjectTokenType = TokenTypeAccessToken
+	}
+	f.exchange = &exchange
+	f.client = client
+	f.cache = make(map[[sha256.Size]byte]exchangedToken)
+	return f, nil
+}
+
+// TokenForwarder returns the token that is forwarded to the upstream API on
+// behalf of the caller of a tool.
+type TokenForwarder struct {
+	authService string
+	exchange    *TokenExchangeConfig
+	client      *http.Client
+
+	mu sync.Mutex
+	// exchanged tokens, by the hash of their subject token
+	cache map[[sha256.Size]byte]exchangedToken
+}
+
+type exchangedToken struct {
+	token  string
+	expiry time.Time
+}
+
+// Token returns the token of the caller, exchanged if a token exchange is
+// configured. It returns an error if the caller didn't send a verified token
+// for the auth service.
+func (f *TokenForwarder) Token(ctx context.Context) (string, error) {
+	token, ok := util.AuthTokenFromContext(ctx, f.authService)
+	if !ok {
+		return "", fmt.Errorf("a verified token of auth service %q is required", f.authService)
+	}
+	if f.exchange == nil {
+		return token, nil
+	}
+
+	key := sha256.Sum256([]byte(token))
+	f.mu.Lock()
+	cached, ok := f.cache[key]
+	f.mu.Unlock()
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.token, nil
+	}
+
+	exchanged, err := f.exchangeToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	// drop expired tokens so that the cache doesn't grow with every caller
+	for k, t := range f.cache {
+		if now.After(t.expiry) {
+			delete(f.cache, k)
+		}
+	}
+	if !exchanged.expiry.IsZero() {
+		f.cache[key] = exchanged
+	}
+	return exchanged.token, nil
+}
+
+// exchangeToken sends the subject token to the token endpoint.
+func (f *TokenForwarder) exchangeToken(ctx context.Context, subjectToken string) (exchangedToken, error) {
+	form := url.Values{
+		"grant_type":         {grantTypeTokenExchange},
+		"subject_token":      {subjectToken},
+		"subject_token_type": {f.exchange.SubjectTokenType},
+	}
+	if f.exchange.Audience != "" {
+		form.Set("audience", f.exchange.Audience)
+	}
+	if f.exchange.Resource != "" {
+		form.Set("resource", f.exchange.Resource)
+	}
+	if len(f.exchange.Scopes) > 0 {
+		form.Set("scope", strings.Join(f.exchange.Scopes, " "))
+	}
+	if f.exchange.RequestedTokenType != "" {
+		form.Set("requested_token_type", f.exchange.RequestedTokenType)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.exchange.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return exchangedToken{}, fmt.Errorf("unable to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if f.exchange.ClientId != "" {
+		req.SetBasicAuth(url.QueryEscape(f.exchange.ClientId), url.QueryEscape(f.exchange.ClientSecret))
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return exchangedToken{}, fmt.Errorf("error making token exchange request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return exchangedToken{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return exchangedToken{}, fmt.Errorf("token exchange failed with status code: %d, response body: %s", resp.StatusCode, string(body))
+	}
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return exchangedToken{}, fmt.Errorf("unable to parse token exchange response: %w", err)
+	}
+	if res.AccessToken == "" {
+		return exchangedToken{}, fmt.Errorf("token exchange response has no access_token")
+	}
+	t := exchangedToken{token: res.AccessToken}
+	// tokens without a lifetime are exchanged for every request
+	if res.ExpiresIn > 0 {
+		t.expiry = time.Now().Add(time.Duration(res.ExpiresIn)*time.Second - expiryDelta)
+	}
+	return t, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestTokenForwarder(t *testing.T) {
+	exchanges := 0
+	sts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		exchanges++
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %s", err)
+		}
+		id, secret, _ := r.BasicAuth()
+		want := map[string]string{
+			"grant_type":         "urn:ietf:params:oauth:grant-type:token-exchange",
+			"subject_token":      "user-token",
+			"subject_token_type": http.TokenTypeAccessToken,
+			"audience":           "https://api.example.com",
+			"scope":              "read write",
+			"client":             "my-client:my-secret",
+		}
+		got := map[string]string{
+			"grant_type":         r.PostForm.Get("grant_type"),
+			"subject_token":      r.PostForm.Get("subject_token"),
+			"subject_token_type": r.PostForm.Get("subject_token_type"),
+			"audience":           r.PostForm.Get("audience"),
+			"scope":              r.PostForm.Get("scope"),
+			"client":             id + ":" + secret,
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("unexpected %s: got %q, want %q", k, got[k], v)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "exchanged-token",
+			"issued_token_type": http.TokenTypeAccessToken,
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		})
+	}))
+	defer sts.Close()
+
+	ctx := util.WithAuthTokens(context.Background(), map[string]string{"my-oidc": "user-token"})
+
+	t.Run("forward", func(t *testing.T) {
+		f, err := http.ForwardAuthConfig{AuthService: "my-oidc"}.Initialize(sts.Client())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, err := f.Token(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "user-token" {
+			t.Fatalf("unexpected token: got %q, want %q", got, "user-token")
+		}
+		if exchanges != 0 {
+			t.Fatalf("token should not be exchanged")
+		}
+	})
+
+	t.Run("exchange", func(t *testing.T) {
+		f, err := http.ForwardAuthConfig{
+			AuthService: "my-oidc",
+			TokenExchange: &http.TokenExchangeConfig{
+				TokenURL:     sts.URL,
+				ClientId:     "my-client",
+				ClientSecret: "my-secret",
+				Audience:     "https://api.example.com",
+				Scopes:       []string{"read", "write"},
+			},
+		}.Initialize(sts.Client())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for i := 0; i < 2; i++ {
+			got, err := f.Token(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != "exchanged-token" {
+				t.Fatalf("unexpected token: got %q, want %q", got, "exchanged-token")
+			}
+		}
+		// the second token is cached
+		if exchanges != 1 {
+			t.Fatalf("unexpected number of exchanges: got %d, want 1", exchanges)
+		}
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		f, err := http.ForwardAuthConfig{AuthService: "other"}.Initialize(sts.Client())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := f.Token(ctx); err == nil {
+			t.Fatalf("expected an error for a caller without a token")
+		}
+	})
+}
@@ -54,6 +54,13 @@ type Config struct {
 	DefaultHeaders         map[string]string `yaml:"headers"`
 	QueryParams            map[string]string `yaml:"queryParams"`
 	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	// TLS configures private CAs and client certificates.
+	TLS *sources.TLSConfig `yaml:"tls"`
+	// Auth configures the credentials sent with every request.
+	Auth *AuthConfig `yaml:"auth"`
+	// ForwardAuth forwards the token of the caller to the API with every
+	// request of the tools of the source.
+	ForwardAuth *ForwardAuthConfig `yaml:"forwardAuth"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -74,10 +81,18 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
 	}
 
+	if r.TLS != nil {
+		tr.TLSClientConfig, err = r.TLS.ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls config for HTTP source %s: %w", r.Name, err)
+		}
+	}
+
 	if r.DisableSslVerification {
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
+		if tr.TLSClientConfig == nil {
+			tr.TLSClientConfig = &tls.Config{}
 		}
+		tr.TLSClientConfig.InsecureSkipVerify = true
 
 		logger.WarnContext(ctx, "Insecure HTTP is enabled for HTTP source %s. TLS certificate verification is skipped.\n", r.Name)
 	}
@@ -86,6 +101,21 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		Timeout:   duration,
 		Transport: tr,
 	}
+	// baseClient doesn't add the credentials of the source, so that they are
+	// only sent to the API
+	baseClient := &http.Client{
+		Timeout:   duration,
+		Transport: tr,
+	}
+	if r.Auth != nil {
+		// tokens are refreshed for as long as the server runs, so they can't
+		// use the context of the initialization
+		authCtx := context.WithoutCancel(ctx)
+		client.Transport, err = r.Auth.Transport(authCtx, baseClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth: %w", err)
+		}
+	}
 
 	// Validate BaseURL
 	_, err = url.ParseRequestURI(r.BaseURL)
@@ -100,6 +130,13 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		DefaultHeaders: r.DefaultHeaders,
 		QueryParams:    r.QueryParams,
 		Client:         &client,
+		BaseClient:     baseClient,
+	}
+	if r.ForwardAuth != nil {
+		s.Forwarder, err = r.ForwardAuth.Initialize(baseClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forwardAuth: %w", err)
+		}
 	}
 	return s, nil
 
@@ -114,6 +151,10 @@ type Source struct {
 	DefaultHeaders map[string]string `yaml:"headers"`
 	QueryParams    map[string]string `yaml:"queryParams"`
 	Client         *http.Client
+	// BaseClient is Client without the auth of the source, used for token
+	// exchanges
+	BaseClient *http.Client
+	Forwarder  *TokenForwarder
 }
 
 func (s *Source) SourceKind() string {
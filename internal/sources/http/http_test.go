@@ -77,6 +77,106 @@ func TestParseFromYamlHttp(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "tls example",
+			in: `
+			sources:
+				my-http-instance:
+					kind: http
+					baseUrl: https://api.internal/
+					tls:
+						caCertFile: /etc/ssl/internal-ca.pem
+						clientCertFile: /etc/ssl/toolbox.pem
+						clientKeyFile: /etc/ssl/toolbox-key.pem
+						serverName: api.internal
+						minVersion: "1.3"
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": http.Config{
+					Name:    "my-http-instance",
+					Kind:    http.SourceKind,
+					BaseURL: "https://api.internal/",
+					Timeout: "30s",
+					TLS: &sources.TLSConfig{
+						CACertFile:     "/etc/ssl/internal-ca.pem",
+						ClientCertFile: "/etc/ssl/toolbox.pem",
+						ClientKeyFile:  "/etc/ssl/toolbox-key.pem",
+						ServerName:     "api.internal",
+						MinVersion:     "1.3",
+					},
+				},
+			},
+		},
+		{
+			desc: "auth example",
+			in: `
+			sources:
+				my-http-instance:
+					kind: http
+					baseUrl: http://test_server/
+					auth:
+						type: client-credentials
+						tokenUrl: https://auth.example.com/token
+						clientId: my-client
+						clientSecret: my-secret
+						scopes:
+							- read
+						endpointParams:
+							audience: https://api.example.com
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": http.Config{
+					Name:    "my-http-instance",
+					Kind:    http.SourceKind,
+					BaseURL: "http://test_server/",
+					Timeout: "30s",
+					Auth: &http.AuthConfig{
+						Type:           http.AuthTypeClientCredentials,
+						TokenURL:       "https://auth.example.com/token",
+						ClientId:       "my-client",
+						ClientSecret:   "my-secret",
+						Scopes:         []string{"read"},
+						EndpointParams: map[string]string{"audience": "https://api.example.com"},
+					},
+				},
+			},
+		},
+		{
+			desc: "forward auth example",
+			in: `
+			sources:
+				my-http-instance:
+					kind: http
+					baseUrl: http://test_server/
+					forwardAuth:
+						authService: my-oidc
+						tokenExchange:
+							tokenUrl: https://sts.example.com/token
+							clientId: my-client
+							clientSecret: my-secret
+							audience: https://api.example.com
+							scopes:
+								- read
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": http.Config{
+					Name:    "my-http-instance",
+					Kind:    http.SourceKind,
+					BaseURL: "http://test_server/",
+					Timeout: "30s",
+					ForwardAuth: &http.ForwardAuthConfig{
+						AuthService: "my-oidc",
+						TokenExchange: &http.TokenExchangeConfig{
+							TokenURL:     "https://sts.example.com/token",
+							ClientId:     "my-client",
+							ClientSecret: "my-secret",
+							Audience:     "https://api.example.com",
+							Scopes:       []string{"read"},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
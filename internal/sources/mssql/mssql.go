@@ -22,7 +22,8 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
-	_ "github.com/microsoft/go-mssqldb"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -47,13 +48,16 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 
 type Config struct {
 	// Cloud SQL MSSQL configs
-	Name     string `yaml:"name" validate:"required"`
-	Kind     string `yaml:"kind" validate:"required"`
-	Host     string `yaml:"host" validate:"required"`
-	Port     string `yaml:"port" validate:"required"`
-	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password" validate:"required"`
-	Database string `yaml:"database" validate:"required"`
+	Name         string                     `yaml:"name" validate:"required"`
+	Kind         string                     `yaml:"kind" validate:"required"`
+	Host         string                     `yaml:"host" validate:"required"`
+	Port         string                     `yaml:"port" validate:"required"`
+	User         string                     `yaml:"user" validate:"required"`
+	Password     string                     `yaml:"password" validate:"required"`
+	Database     string                     `yaml:"database" validate:"required"`
+	TLS          *sources.DatabaseTLSConfig `yaml:"tls"`
+	QueryParams  map[string]string          `yaml:"queryParams"`
+	SessionSetup sources.SessionSetup       `yaml:"sessionSetup"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -61,9 +65,20 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+// tlsQueryParams are the TLS parameters of go-mssqldb, which can't be set along
+// with the tls block
+var tlsQueryParams = []string{"encrypt", "TrustServerCertificate", "certificate", "hostNameInCertificate", "tlsmin", "ServerCertificate"}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if err := r.SessionSetup.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.TLS.CheckQueryParams(r.QueryParams, tlsQueryParams...); err != nil {
+		return nil, err
+	}
+
 	// Initializes a MSSQL source
-	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database)
+	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
@@ -75,9 +90,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name: r.Name,
-		Kind: SourceKind,
-		Db:   db,
+		Name:         r.Name,
+		Kind:         SourceKind,
+		Db:           db,
+		SessionSetup: r.SessionSetup,
 	}
 	return s, nil
 }
@@ -86,9 +102,10 @@ var _ sources.Source = &Source{}
 
 type Source struct {
 	// Cloud SQL MSSQL struct with connection pool
-	Name string `yaml:"name"`
-	Kind string `yaml:"kind"`
-	Db   *sql.DB
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	Db           *sql.DB
+	SessionSetup sources.SessionSetup
 }
 
 func (s *Source) SourceKind() string {
@@ -101,25 +118,44 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
-func initMssqlConnection(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string) (*sql.DB, error) {
+func (s *Source) MSSQLSessionSetup() sources.SessionSetup {
+	return s.SessionSetup
+}
+
+func initMssqlConnection(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, tlsConfig *sources.DatabaseTLSConfig) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
 	// Create dsn
 	query := url.Values{}
-	query.Add("database", dbname)
+	for k, v := range queryParams {
+		query.Add(k, v)
+	}
+	query.Set("database", dbname)
 	url := &url.URL{
 		Scheme:   "sqlserver",
 		User:     url.UserPassword(user, pass),
 		Host:     fmt.Sprintf("%s:%s", host, port),
 		RawQuery: query.Encode(),
 	}
-
-	// Open database connection
-	db, err := sql.Open("sqlserver", url.String())
+	config, err := msdsn.Parse(url.String())
 	if err != nil {
-		return nil, fmt.Errorf("sql.Open: %w", err)
+		return nil, fmt.Errorf("unable to parse dsn: %w", err)
 	}
-	return db, nil
+	if tlsConfig != nil {
+		config.TLSConfig, err = tlsConfig.DatabaseClientConfig(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls config: %w", err)
+		}
+		config.Encryption = msdsn.EncryptionRequired
+		if config.TLSConfig == nil {
+			config.Encryption = msdsn.EncryptionDisabled
+		}
+		// keep the server name of the tls config
+		config.HostInCertificateProvided = true
+	}
+
+	// Open database connection
+	return sql.OpenDB(mssql.NewConnectorConfig(config)), nil
 }
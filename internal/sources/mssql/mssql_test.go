@@ -20,6 +20,7 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 )
@@ -54,6 +55,83 @@ func TestParseFromYamlMssql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with tls",
+			in: `
+			sources:
+				my-mssql-instance:
+					kind: mssql
+					host: 0.0.0.0
+					port: my-port
+					database: my_db
+					user: my_user
+					password: my_pass
+					tls:
+						mode: verify-ca
+						caCertFile: /etc/ssl/db-ca.pem
+						clientCertFile: /etc/ssl/client.pem
+						clientKeyFile: /etc/ssl/client-key.pem
+						serverName: db.internal
+					queryParams:
+						app name: toolbox
+			`,
+			want: server.SourceConfigs{
+				"my-mssql-instance": mssql.Config{
+					Name:     "my-mssql-instance",
+					Kind:     mssql.SourceKind,
+					Host:     "0.0.0.0",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					TLS: &sources.DatabaseTLSConfig{
+						Mode: sources.TLSModeVerifyCA,
+						TLSConfig: sources.TLSConfig{
+							CACertFile:     "/etc/ssl/db-ca.pem",
+							ClientCertFile: "/etc/ssl/client.pem",
+							ClientKeyFile:  "/etc/ssl/client-key.pem",
+							ServerName:     "db.internal",
+						},
+					},
+					QueryParams: map[string]string{"app name": "toolbox"},
+				},
+			},
+		},
+		{
+			desc: "with session setup",
+			in: `
+			sources:
+				my-mssql-instance:
+					kind: mssql
+					host: 0.0.0.0
+					port: my-port
+					database: my_db
+					user: my_user
+					password: my_pass
+					sessionSetup:
+						- statement: EXEC sp_set_session_context @key = N'user_email', @value = @p1
+							claims:
+								- authService: my-google-auth
+									claim: email
+			`,
+			want: server.SourceConfigs{
+				"my-mssql-instance": mssql.Config{
+					Name:     "my-mssql-instance",
+					Kind:     mssql.SourceKind,
+					Host:     "0.0.0.0",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					SessionSetup: sources.SessionSetup{
+						{
+							Statement: "EXEC sp_set_session_context @key = N'user_email', @value = @p1",
+							Claims:    []sources.SessionClaim{{AuthService: "my-google-auth", Claim: "email"}},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
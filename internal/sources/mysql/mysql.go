@@ -18,8 +18,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"go.opentelemetry.io/otel/trace"
@@ -45,21 +49,38 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 }
 
 type Config struct {
-	Name     string `yaml:"name" validate:"required"`
-	Kind     string `yaml:"kind" validate:"required"`
-	Host     string `yaml:"host" validate:"required"`
-	Port     string `yaml:"port" validate:"required"`
-	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password" validate:"required"`
-	Database string `yaml:"database" validate:"required"`
+	Name         string                     `yaml:"name" validate:"required"`
+	Kind         string                     `yaml:"kind" validate:"required"`
+	Host         string                     `yaml:"host" validate:"required"`
+	Port         string                     `yaml:"port" validate:"required"`
+	User         string                     `yaml:"user" validate:"required"`
+	Password     string                     `yaml:"password" validate:"required"`
+	Database     string                     `yaml:"database" validate:"required"`
+	TLS          *sources.DatabaseTLSConfig `yaml:"tls"`
+	QueryParams  map[string]string          `yaml:"queryParams"`
+	SessionSetup sources.SessionSetup       `yaml:"sessionSetup"`
 }
 
 func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+// tlsQueryParams are the TLS parameters of go-sql-driver/mysql, which can't
+// be set along with the tls block
+var tlsQueryParams = []string{"tls"}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initMySQLConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database)
+	if err := r.SessionSetup.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.TLS.CheckQueryParams(r.QueryParams, tlsQueryParams...); err != nil {
+		return nil, err
+	}
+	if _, ok := r.QueryParams["parseTime"]; ok {
+		return nil, fmt.Errorf("query parameter \"parseTime\" can't be set, DATE and DATETIME values are always parsed")
+	}
+
+	pool, err := initMySQLConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -70,9 +91,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name: r.Name,
-		Kind: SourceKind,
-		Pool: pool,
+		Name:         r.Name,
+		Kind:         SourceKind,
+		Pool:         pool,
+		SessionSetup: r.SessionSetup,
 	}
 	return s, nil
 }
@@ -80,9 +102,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 var _ sources.Source = &Source{}
 
 type Source struct {
-	Name string `yaml:"name"`
-	Kind string `yaml:"kind"`
-	Pool *sql.DB
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	Pool         *sql.DB
+	SessionSetup sources.SessionSetup
 }
 
 func (s *Source) SourceKind() string {
@@ -93,18 +116,115 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
-func initMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string) (*sql.DB, error) {
+func (s *Source) MySQLSessionSetup() sources.SessionSetup {
+	return s.SessionSetup
+}
+
+func initMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, tlsConfig *sources.DatabaseTLSConfig) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
 	// Configure the driver to connect to the database
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", user, pass, host, port, dbname)
+	config := mysql.NewConfig()
+	config.User = user
+	config.Passwd = pass
+	config.Net = "tcp"
+	config.Addr = net.JoinHostPort(host, port)
+	config.DBName = dbname
+	config.ParseTime = true
+	if err := applyQueryParams(config, queryParams); err != nil {
+		return nil, fmt.Errorf("invalid query parameters: %w", err)
+	}
+	if tlsConfig != nil {
+		var err error
+		config.TLS, err = tlsConfig.DatabaseClientConfig(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls config: %w", err)
+		}
+	}
 
-	// Interact with the driver directly as you normally would
-	pool, err := sql.Open("mysql", dsn)
+	connector, err := mysql.NewConnector(config)
 	if err != nil {
-		return nil, fmt.Errorf("sql.Open: %w", err)
+		return nil, fmt.Errorf("unable to create connector: %w", err)
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// applyQueryParams sets query parameters on the config of the driver. The
+// parameters of the driver set the matching options, and the other parameters
+// are system variables set on each connection.
+func applyQueryParams(config *mysql.Config, queryParams map[string]string) error {
+	for k, v := range queryParams {
+		var err error
+		switch k {
+		case "allowAllFiles":
+			config.AllowAllFiles, err = strconv.ParseBool(v)
+		case "allowCleartextPasswords":
+			config.AllowCleartextPasswords, err = strconv.ParseBool(v)
+		case "allowFallbackToPlaintext":
+			config.AllowFallbackToPlaintext, err = strconv.ParseBool(v)
+		case "allowNativePasswords":
+			config.AllowNativePasswords, err = strconv.ParseBool(v)
+		case "allowOldPasswords":
+			config.AllowOldPasswords, err = strconv.ParseBool(v)
+		case "checkConnLiveness":
+			config.CheckConnLiveness, err = strconv.ParseBool(v)
+		case "clientFoundRows":
+			config.ClientFoundRows, err = strconv.ParseBool(v)
+		case "columnsWithAlias":
+			config.ColumnsWithAlias, err = strconv.ParseBool(v)
+		case "interpolateParams":
+			config.InterpolateParams, err = strconv.ParseBool(v)
+		case "multiStatements":
+			config.MultiStatements, err = strconv.ParseBool(v)
+		case "rejectReadOnly":
+			config.RejectReadOnly, err = strconv.ParseBool(v)
+		case "compress":
+			var compress bool
+			if compress, err = strconv.ParseBool(v); err == nil {
+				err = config.Apply(mysql.EnableCompression(compress))
+			}
+		case "charset":
+			// the driver can only be configured with the first charset of
+			// a list
+			charset, _, _ := strings.Cut(v, ",")
+			err = config.Apply(mysql.Charset(charset, config.Collation))
+		case "collation":
+			config.Collation = v
+		case "loc":
+			config.Loc, err = time.LoadLocation(v)
+		case "timeTruncate":
+			var d time.Duration
+			if d, err = time.ParseDuration(v); err == nil {
+				err = config.Apply(mysql.TimeTruncate(d))
+			}
+		case "timeout":
+			config.Timeout, err = time.ParseDuration(v)
+		case "readTimeout":
+			config.ReadTimeout, err = time.ParseDuration(v)
+		case "writeTimeout":
+			config.WriteTimeout, err = time.ParseDuration(v)
+		case "maxAllowedPacket":
+			config.MaxAllowedPacket, err = strconv.Atoi(v)
+		case "serverPubKey":
+			config.ServerPubKey = v
+		case "tls":
+			config.TLSConfig = v
+			if b, boolErr := strconv.ParseBool(v); boolErr == nil {
+				config.TLSConfig = strconv.FormatBool(b)
+			}
+		case "connectionAttributes":
+			config.ConnectionAttributes = v
+		default:
+			if config.Params == nil {
+				config.Params = make(map[string]string)
+			}
+			config.Params[k] = v
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value %q for query parameter %q: %w", v, k, err)
+		}
 	}
-	return pool, nil
+	return nil
 }
@@ -15,13 +15,16 @@
 package mysql_test
 
 import (
+	"context"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlCloudSQLMySQL(t *testing.T) {
@@ -54,6 +57,48 @@ func TestParseFromYamlCloudSQLMySQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with tls",
+			in: `
+			sources:
+				my-mysql-instance:
+					kind: mysql
+					host: 0.0.0.0
+					port: my-port
+					database: my_db
+					user: my_user
+					password: my_pass
+					tls:
+						mode: verify-ca
+						caCertFile: /etc/ssl/db-ca.pem
+						clientCertFile: /etc/ssl/client.pem
+						clientKeyFile: /etc/ssl/client-key.pem
+						serverName: db.internal
+					queryParams:
+						timeout: 10s
+			`,
+			want: server.SourceConfigs{
+				"my-mysql-instance": mysql.Config{
+					Name:     "my-mysql-instance",
+					Kind:     mysql.SourceKind,
+					Host:     "0.0.0.0",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					TLS: &sources.DatabaseTLSConfig{
+						Mode: sources.TLSModeVerifyCA,
+						TLSConfig: sources.TLSConfig{
+							CACertFile:     "/etc/ssl/db-ca.pem",
+							ClientCertFile: "/etc/ssl/client.pem",
+							ClientKeyFile:  "/etc/ssl/client-key.pem",
+							ServerName:     "db.internal",
+						},
+					},
+					QueryParams: map[string]string{"timeout": "10s"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -125,3 +170,40 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestFailInitializeQueryParams(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		queryParams map[string]string
+		err         string
+	}{
+		{
+			desc:        "parseTime",
+			queryParams: map[string]string{"parseTime": "false"},
+			err:         `query parameter "parseTime" can't be set, DATE and DATETIME values are always parsed`,
+		},
+		{
+			desc:        "invalid driver parameter",
+			queryParams: map[string]string{"timeout": "soon"},
+			err:         `unable to create pool: invalid query parameters: invalid value "soon" for query parameter "timeout": time: invalid duration "soon"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := mysql.Config{
+				Name:        "my-mysql-instance",
+				Kind:        mysql.SourceKind,
+				Host:        "127.0.0.1",
+				Port:        "3306",
+				Database:    "my_db",
+				User:        "my_user",
+				Password:    "my_pass",
+				QueryParams: tc.queryParams,
+			}
+			_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
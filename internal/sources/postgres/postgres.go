@@ -45,21 +45,35 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources
 }
 
 type Config struct {
-	Name     string `yaml:"name" validate:"required"`
-	Kind     string `yaml:"kind" validate:"required"`
-	Host     string `yaml:"host" validate:"required"`
-	Port     string `yaml:"port" validate:"required"`
-	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password" validate:"required"`
-	Database string `yaml:"database" validate:"required"`
+	Name         string                     `yaml:"name" validate:"required"`
+	Kind         string                     `yaml:"kind" validate:"required"`
+	Host         string                     `yaml:"host" validate:"required"`
+	Port         string                     `yaml:"port" validate:"required"`
+	User         string                     `yaml:"user" validate:"required"`
+	Password     string                     `yaml:"password" validate:"required"`
+	Database     string                     `yaml:"database" validate:"required"`
+	TLS          *sources.DatabaseTLSConfig `yaml:"tls"`
+	QueryParams  map[string]string          `yaml:"queryParams"`
+	SessionSetup sources.SessionSetup       `yaml:"sessionSetup"`
 }
 
 func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+// tlsQueryParams are the TLS parameters of pgx, which can't be set along
+// with the tls block
+var tlsQueryParams = []string{"sslmode", "sslrootcert", "sslcert", "sslkey", "sslpassword", "sslsni", "sslnegotiation"}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database)
+	if err := r.SessionSetup.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.TLS.CheckQueryParams(r.QueryParams, tlsQueryParams...); err != nil {
+		return nil, err
+	}
+
+	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.QueryParams, r.TLS)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -70,9 +84,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	}
 
 	s := &Source{
-		Name: r.Name,
-		Kind: SourceKind,
-		Pool: pool,
+		Name:         r.Name,
+		Kind:         SourceKind,
+		Pool:         pool,
+		SessionSetup: r.SessionSetup,
 	}
 	return s, nil
 }
@@ -80,9 +95,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 var _ sources.Source = &Source{}
 
 type Source struct {
-	Name string `yaml:"name"`
-	Kind string `yaml:"kind"`
-	Pool *pgxpool.Pool
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	Pool         *pgxpool.Pool
+	SessionSetup sources.SessionSetup
 }
 
 func (s *Source) SourceKind() string {
@@ -93,19 +109,40 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
-func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string) (*pgxpool.Pool, error) {
+func (s *Source) PostgresSessionSetup() sources.SessionSetup {
+	return s.SessionSetup
+}
+
+func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, queryParams map[string]string, tlsConfig *sources.DatabaseTLSConfig) (*pgxpool.Pool, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
 	// urlExample := "postgres:dd//username:password@localhost:5432/database_name"
+	query := url.Values{}
+	for k, v := range queryParams {
+		query.Add(k, v)
+	}
 	url := &url.URL{
-		Scheme: "postgres",
-		User:   url.UserPassword(user, pass),
-		Host:   fmt.Sprintf("%s:%s", host, port),
-		Path:   dbname,
+		Scheme:   "postgres",
+		User:     url.UserPassword(user, pass),
+		Host:     fmt.Sprintf("%s:%s", host, port),
+		Path:     dbname,
+		RawQuery: query.Encode(),
+	}
+	config, err := pgxpool.ParseConfig(url.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+	}
+	if tlsConfig != nil {
+		config.ConnConfig.TLSConfig, err = tlsConfig.DatabaseClientConfig(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls config: %w", err)
+		}
+		// never fall back to another sslmode
+		config.ConnConfig.Fallbacks = nil
 	}
-	pool, err := pgxpool.New(ctx, url.String())
+	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
@@ -20,6 +20,7 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 )
@@ -54,6 +55,85 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with tls",
+			in: `
+			sources:
+				my-pg-instance:
+					kind: postgres
+					host: my-host
+					port: my-port
+					database: my_db
+					user: my_user
+					password: my_pass
+					tls:
+						mode: verify-ca
+						caCertFile: /etc/ssl/db-ca.pem
+						clientCertFile: /etc/ssl/client.pem
+						clientKeyFile: /etc/ssl/client-key.pem
+						serverName: db.internal
+					queryParams:
+						application_name: toolbox
+			`,
+			want: server.SourceConfigs{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Kind:     postgres.SourceKind,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					TLS: &sources.DatabaseTLSConfig{
+						Mode: sources.TLSModeVerifyCA,
+						TLSConfig: sources.TLSConfig{
+							CACertFile:     "/etc/ssl/db-ca.pem",
+							ClientCertFile: "/etc/ssl/client.pem",
+							ClientKeyFile:  "/etc/ssl/client-key.pem",
+							ServerName:     "db.internal",
+						},
+					},
+					QueryParams: map[string]string{"application_name": "toolbox"},
+				},
+			},
+		},
+		{
+			desc: "with session setup",
+			in: `
+			sources:
+				my-pg-instance:
+					kind: postgres
+					host: my-host
+					port: my-port
+					database: my_db
+					user: my_user
+					password: my_pass
+					sessionSetup:
+						- statement: SET LOCAL ROLE app_user
+						- statement: SELECT set_config('app.user_email', $1, true)
+							claims:
+								- authService: my-google-auth
+									claim: email
+			`,
+			want: server.SourceConfigs{
+				"my-pg-instance": postgres.Config{
+					Name:     "my-pg-instance",
+					Kind:     postgres.SourceKind,
+					Host:     "my-host",
+					Port:     "my-port",
+					Database: "my_db",
+					User:     "my_user",
+					Password: "my_pass",
+					SessionSetup: sources.SessionSetup{
+						{Statement: "SET LOCAL ROLE app_user"},
+						{
+							Statement: "SELECT set_config('app.user_email', $1, true)",
+							Claims:    []sources.SessionClaim{{AuthService: "my-google-auth", Claim: "email"}},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
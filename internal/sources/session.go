@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SessionSetup is a list of statements that are run before the statement of
+// every tool invocation on a source, in the same transaction. Their arguments
+// are taken from the claims verified for the invocation, so that the database
+// can apply policies, such as row-level security, to the caller.
+type SessionSetup []SessionStatement
+
+// SessionStatement is a statement of the session setup.
+type SessionStatement struct {
+	Statement string `yaml:"statement" validate:"required"`
+	// Claims are bound in order to the placeholders of the statement.
+	Claims []SessionClaim `yaml:"claims"`
+}
+
+// SessionClaim is a claim retrieved from an auth service.
+type SessionClaim struct {
+	AuthService string `yaml:"authService" validate:"required"`
+	Claim       string `yaml:"claim" validate:"required"`
+}
+
+// Validate returns an error if a statement of the session setup is invalid.
+func (s SessionSetup) Validate() error {
+	for i, st := range s {
+		if st.Statement == "" {
+			return fmt.Errorf("statement %d of sessionSetup must not be empty", i)
+		}
+		for _, c := range st.Claims {
+			if c.AuthService == "" || c.Claim == "" {
+				return fmt.Errorf("claims of sessionSetup statement %d must set both authService and claim", i)
+			}
+		}
+	}
+	return nil
+}
+
+// args returns the arguments of each statement from the claims of the caller.
+// Invocations without every claim are rejected, so that a statement is never
+// run without its arguments.
+func (s SessionSetup) args(claimsFromAuth map[string]map[string]any) ([][]any, error) {
+	args := make([][]any, len(s))
+	for i, st := range s {
+		for _, c := range st.Claims {
+			v, ok := claimsFromAuth[c.AuthService][c.Claim]
+			if !ok {
+				return nil, fmt.Errorf("session setup requires the %q claim of auth service %q", c.Claim, c.AuthService)
+			}
+			args[i] = append(args[i], v)
+		}
+	}
+	return args, nil
+}
+
+// PostgresQuerier runs queries on a pgx pool or transaction.
+type PostgresQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// RunPostgres calls fn with the pool if there is no session setup. Otherwise,
+// it runs the session setup in a new transaction and calls fn with the
+// transaction, which is committed if fn succeeds. fn must read its rows
+// before returning.
+func (s SessionSetup) RunPostgres(ctx context.Context, pool *pgxpool.Pool, fn func(PostgresQuerier) error) error {
+	if len(s) == 0 {
+		return fn(pool)
+	}
+	args, err := s.args(util.AuthClaimsFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		for i, st := range s {
+			if _, err := tx.Exec(ctx, st.Statement, args[i]...); err != nil {
+				return fmt.Errorf("unable to run session setup: %w", err)
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// SQLQuerier runs queries on a database/sql pool or transaction.
+type SQLQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// RunSQL calls fn with the pool if there is no session setup. Otherwise, it
+// runs the session setup in a new transaction and calls fn with the
+// transaction, which is committed if fn succeeds. fn must close its rows
+// before returning.
+func (s SessionSetup) RunSQL(ctx context.Context, db *sql.DB, fn func(SQLQuerier) error) error {
+	if len(s) == 0 {
+		return fn(db)
+	}
+	args, err := s.args(util.AuthClaimsFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	// rolling back is a no-op once the transaction is committed
+	defer func() { _ = tx.Rollback() }()
+	for i, st := range s {
+		if _, err := tx.ExecContext(ctx, st.Statement, args[i]...); err != nil {
+			return fmt.Errorf("unable to run session setup: %w", err)
+		}
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return nil
+}
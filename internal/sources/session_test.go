@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+var testSessionSetup = SessionSetup{
+	{Statement: "SET LOCAL ROLE app_user"},
+	{
+		Statement: "SELECT set_config('app.user_email', $1, true), set_config('app.tenant', $2, true)",
+		Claims: []SessionClaim{
+			{AuthService: "my-google-auth", Claim: "email"},
+			{AuthService: "my-oidc", Claim: "tenant"},
+		},
+	},
+}
+
+func TestSessionSetupArgs(t *testing.T) {
+	claims := map[string]map[string]any{
+		"my-google-auth": {"email": "jane@corp.com"},
+		"my-oidc":        {"tenant": "acme"},
+	}
+	got, err := testSessionSetup.args(claims)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := [][]any{nil, {"jane@corp.com", "acme"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect args: diff %v", diff)
+	}
+
+	// a missing claim must fail the invocation
+	delete(claims, "my-oidc")
+	if _, err := testSessionSetup.args(claims); err == nil {
+		t.Fatalf("expected error for missing claim")
+	}
+}
+
+func TestSessionSetupMissingClaims(t *testing.T) {
+	ctx := util.WithAuthClaims(context.Background(), map[string]map[string]any{})
+	called := false
+	fn := func(SQLQuerier) error {
+		called = true
+		return nil
+	}
+	// the setup fails before the database is used
+	if err := testSessionSetup.RunSQL(ctx, nil, fn); err == nil {
+		t.Fatalf("expected error for missing claims")
+	}
+	if called {
+		t.Fatalf("query was run without session setup")
+	}
+	// without session setup, queries run on the pool
+	if err := (SessionSetup{}).RunSQL(ctx, nil, fn); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !called {
+		t.Fatalf("query was not run")
+	}
+}
+
+func TestSessionSetupValidate(t *testing.T) {
+	if err := testSessionSetup.Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	invalid := []SessionSetup{
+		{{Statement: ""}},
+		{{Statement: "SELECT set_config('app.user_email', $1, true)", Claims: []SessionClaim{{Claim: "email"}}}},
+	}
+	for _, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Fatalf("expected error for %v", s)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// TLSConfig configures the TLS connections of a source to its server.
+// Certificates and keys are PEM encoded, and can be set either inline or as
+// the path of a file.
+type TLSConfig struct {
+	// CACert verifies the server with a private CA instead of the system
+	// roots.
+	CACert     string `yaml:"caCert"`
+	CACertFile string `yaml:"caCertFile"`
+	// ClientCert and ClientKey are sent to servers that require mutual TLS.
+	ClientCert     string `yaml:"clientCert"`
+	ClientCertFile string `yaml:"clientCertFile"`
+	ClientKey      string `yaml:"clientKey"`
+	ClientKeyFile  string `yaml:"clientKeyFile"`
+	// ServerName overrides the host name that the certificate of the server
+	// is verified against.
+	ServerName string `yaml:"serverName"`
+	// MinVersion is the minimum TLS version, e.g. "1.2" or "1.3".
+	MinVersion string `yaml:"minVersion"`
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ClientConfig returns the tls.Config of a client connecting to the server.
+func (c TLSConfig) ClientConfig() (*tls.Config, error) {
+	cfg := &tls.Config{ServerName: c.ServerName}
+
+	caCert, err := pemValue("caCert", c.CACert, c.CACertFile)
+	if err != nil {
+		return nil, err
+	}
+	if caCert != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("caCert doesn't contain a valid PEM certificate")
+		}
+		cfg.RootCAs = pool
+	}
+
+	clientCert, err := pemValue("clientCert", c.ClientCert, c.ClientCertFile)
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := pemValue("clientKey", c.ClientKey, c.ClientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if (clientCert == nil) != (clientKey == nil) {
+		return nil, fmt.Errorf("clientCert and clientKey must be set together")
+	}
+	if clientCert != nil {
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.MinVersion != "" {
+		v, ok := tlsVersions[c.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid minVersion %q: must be one of 1.0, 1.1, 1.2 or 1.3", c.MinVersion)
+		}
+		cfg.MinVersion = v
+	}
+	return cfg, nil
+}
+
+// pemValue returns the inline value, or the contents of the file. It returns
+// nil if neither is set.
+func pemValue(field, value, file string) ([]byte, error) {
+	switch {
+	case value != "" && file != "":
+		return nil, fmt.Errorf("only one of %s and %sFile can be set", field, field)
+	case value != "":
+		return []byte(value), nil
+	case file != "":
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %sFile: %w", field, err)
+		}
+		return b, nil
+	}
+	return nil, nil
+}
+
+const (
+	TLSModeDisable    = "disable"
+	TLSModeRequire    = "require"
+	TLSModeVerifyCA   = "verify-ca"
+	TLSModeVerifyFull = "verify-full"
+)
+
+// DatabaseTLSConfig configures the TLS connections of a self-hosted database
+// source.
+type DatabaseTLSConfig struct {
+	// Mode is one of disable, require, verify-ca or verify-full. Defaults to
+	// verify-full.
+	Mode      string `yaml:"mode"`
+	TLSConfig `yaml:",inline"`
+}
+
+// DatabaseClientConfig returns the tls.Config of a client connecting to the
+// database on host, or nil if TLS is disabled.
+func (c DatabaseTLSConfig) DatabaseClientConfig(host string) (*tls.Config, error) {
+	mode := c.Mode
+	if mode == "" {
+		mode = TLSModeVerifyFull
+	}
+	switch mode {
+	case TLSModeDisable:
+		return nil, nil
+	case TLSModeRequire, TLSModeVerifyCA, TLSModeVerifyFull:
+	default:
+		return nil, fmt.Errorf("invalid tls mode %q: must be one of %q", c.Mode, []string{TLSModeDisable, TLSModeRequire, TLSModeVerifyCA, TLSModeVerifyFull})
+	}
+
+	cfg, err := c.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	switch mode {
+	case TLSModeRequire:
+		// the connection is encrypted, but the server is not verified
+		cfg.InsecureSkipVerify = true
+	case TLSModeVerifyCA:
+		// the certificate chain is verified, but not the host name
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = verifyChain(cfg.RootCAs)
+	case TLSModeVerifyFull:
+		if cfg.ServerName == "" {
+			cfg.ServerName = host
+		}
+	}
+	return cfg, nil
+}
+
+// CheckQueryParams returns an error if one of tlsParams, the TLS parameters of
+// the driver, is set in queryParams along with the tls block, since the tls
+// block would silently override it. Parameters are compared case
+// insensitively. A nil config allows every parameter.
+func (c *DatabaseTLSConfig) CheckQueryParams(queryParams map[string]string, tlsParams ...string) error {
+	if c == nil {
+		return nil
+	}
+	for _, k := range slices.Sorted(maps.Keys(queryParams)) {
+		for _, p := range tlsParams {
+			if strings.EqualFold(k, p) {
+				return fmt.Errorf("queryParams %q can't be set along with tls, use the fields of tls instead", k)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyChain verifies the certificate of the server against roots, or the
+// system roots if roots is nil.
+func verifyChain(roots *x509.CertPool) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("server didn't send a certificate")
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCert is a PEM encoded certificate and key.
+type testCert struct {
+	cert, key []byte
+	x509      *x509.Certificate
+	priv      *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by parent, or a self-signed CA if
+// parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert) testCert {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, priv
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.x509, parent.priv
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &priv.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+	parsed, _ := x509.ParseCertificate(der)
+	return testCert{
+		cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		x509: parsed,
+		priv: priv,
+	}
+}
+
+func TestTLSClientConfig(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil)
+	server := newTestCert(t, "db.internal", &ca)
+	client := newTestCert(t, "toolbox", &ca)
+
+	serverCert, err := tls.X509KeyPair(server.cert, server.key)
+	if err != nil {
+		t.Fatalf("unable to load server certificate: %s", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.x509)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, ca.cert, 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		in      TLSConfig
+		success bool
+	}{
+		{
+			desc: "mutual tls",
+			in: TLSConfig{
+				CACertFile: caFile,
+				ClientCert: string(client.cert),
+				ClientKey:  string(client.key),
+				ServerName: "db.internal",
+				MinVersion: "1.2",
+			},
+			success: true,
+		},
+		{
+			desc:    "without client certificate",
+			in:      TLSConfig{CACert: string(ca.cert), ServerName: "db.internal"},
+			success: false,
+		},
+		{
+			desc: "wrong server name",
+			in: TLSConfig{
+				CACert:     string(ca.cert),
+				ClientCert: string(client.cert),
+				ClientKey:  string(client.key),
+				ServerName: "other.internal",
+			},
+			success: false,
+		},
+		{
+			desc: "system roots",
+			in: TLSConfig{
+				ClientCert: string(client.cert),
+				ClientKey:  string(client.key),
+				ServerName: "db.internal",
+			},
+			success: false,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.in.ClientConfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			c := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+			resp, err := c.Get(ts.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tc.success != (err == nil) {
+				t.Fatalf("unexpected result: want success %t, got error %v", tc.success, err)
+			}
+		})
+	}
+}
+
+func TestTLSClientConfigInvalid(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil)
+	tcs := []struct {
+		desc string
+		in   TLSConfig
+		err  string
+	}{
+		{
+			desc: "ca inline and file",
+			in:   TLSConfig{CACert: string(ca.cert), CACertFile: "ca.pem"},
+			err:  "only one of caCert and caCertFile can be set",
+		},
+		{
+			desc: "invalid ca",
+			in:   TLSConfig{CACert: "not a certificate"},
+			err:  "caCert doesn't contain a valid PEM certificate",
+		},
+		{
+			desc: "cert without key",
+			in:   TLSConfig{ClientCert: string(ca.cert)},
+			err:  "clientCert and clientKey must be set together",
+		},
+		{
+			desc: "invalid min version",
+			in:   TLSConfig{MinVersion: "1.4"},
+			err:  `invalid minVersion "1.4": must be one of 1.0, 1.1, 1.2 or 1.3`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.in.ClientConfig()
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestDatabaseClientConfig(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil)
+	server := newTestCert(t, "db.internal", &ca)
+	serverCert, err := tls.X509KeyPair(server.cert, server.key)
+	if err != nil {
+		t.Fatalf("unable to load server certificate: %s", err)
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	ts.StartTLS()
+	defer ts.Close()
+
+	tcs := []struct {
+		desc    string
+		in      DatabaseTLSConfig
+		host    string
+		success bool
+	}{
+		{
+			desc:    "verify-full by default",
+			in:      DatabaseTLSConfig{TLSConfig: TLSConfig{CACert: string(ca.cert)}},
+			host:    "db.internal",
+			success: true,
+		},
+		{
+			desc:    "verify-full with wrong host",
+			in:      DatabaseTLSConfig{Mode: TLSModeVerifyFull, TLSConfig: TLSConfig{CACert: string(ca.cert)}},
+			host:    "other.internal",
+			success: false,
+		},
+		{
+			desc:    "verify-ca with wrong host",
+			in:      DatabaseTLSConfig{Mode: TLSModeVerifyCA, TLSConfig: TLSConfig{CACert: string(ca.cert)}},
+			host:    "other.internal",
+			success: true,
+		},
+		{
+			desc:    "verify-ca with system roots",
+			in:      DatabaseTLSConfig{Mode: TLSModeVerifyCA},
+			host:    "db.internal",
+			success: false,
+		},
+		{
+			desc:    "require",
+			in:      DatabaseTLSConfig{Mode: TLSModeRequire},
+			host:    "other.internal",
+			success: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg, err := tc.in.DatabaseClientConfig(tc.host)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			c := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+			resp, err := c.Get(ts.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tc.success != (err == nil) {
+				t.Fatalf("unexpected result: want success %t, got error %v", tc.success, err)
+			}
+		})
+	}
+
+	t.Run("disable", func(t *testing.T) {
+		cfg, err := DatabaseTLSConfig{Mode: TLSModeDisable}.DatabaseClientConfig("db.internal")
+		if err != nil || cfg != nil {
+			t.Fatalf("expected no tls config, got %v, %v", cfg, err)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		if _, err := (DatabaseTLSConfig{Mode: "prefer"}).DatabaseClientConfig("db.internal"); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestCheckQueryParams(t *testing.T) {
+	tlsParams := []string{"sslmode", "sslrootcert"}
+	tcs := []struct {
+		desc        string
+		in          *DatabaseTLSConfig
+		queryParams map[string]string
+		err         string
+	}{
+		{
+			desc:        "no tls block",
+			queryParams: map[string]string{"sslmode": "require"},
+		},
+		{
+			desc:        "other params",
+			in:          &DatabaseTLSConfig{},
+			queryParams: map[string]string{"application_name": "toolbox"},
+		},
+		{
+			desc:        "tls param",
+			in:          &DatabaseTLSConfig{Mode: TLSModeRequire},
+			queryParams: map[string]string{"application_name": "toolbox", "sslmode": "disable"},
+			err:         `queryParams "sslmode" can't be set along with tls, use the fields of tls instead`,
+		},
+		{
+			desc:        "tls param with another case",
+			in:          &DatabaseTLSConfig{},
+			queryParams: map[string]string{"SSLRootCert": "/etc/ssl/ca.pem"},
+			err:         `queryParams "SSLRootCert" can't be set along with tls, use the fields of tls instead`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.in.CheckQueryParams(tc.queryParams, tlsParams...)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 
 type compatibleSource interface {
 	PostgresPool() *pgxpool.Pool
+	PostgresSessionSetup() sources.SessionSetup
 }
 
 // validate compatible sources are still compatible
@@ -52,13 +53,17 @@ var _ compatibleSource = &alloydbpg.Source{}
 var compatibleSources = [...]string{alloydbpg.SourceKind}
 
 type Config struct {
-	Name               string           `yaml:"name" validate:"required"`
-	Kind               string           `yaml:"kind" validate:"required"`
-	Source             string           `yaml:"source" validate:"required"`
-	Description        string           `yaml:"description" validate:"required"`
-	NLConfig           string           `yaml:"nlConfig" validate:"required"`
-	AuthRequired       []string         `yaml:"authRequired"`
-	NLConfigParameters tools.Parameters `yaml:"nlConfigParameters"`
+	Name               string                 `yaml:"name" validate:"required"`
+	Kind               string                 `yaml:"kind" validate:"required"`
+	Source             string                 `yaml:"source" validate:"required"`
+	Description        string                 `yaml:"description" validate:"required"`
+	Title              string                 `yaml:"title"`
+	ResultSchema       map[string]any         `yaml:"resultSchema"`
+	Annotations        *tools.ToolAnnotations `yaml:"annotations"`
+	Policy             *tools.Policy          `yaml:"policy"`
+	NLConfig           string                 `yaml:"nlConfig" validate:"required"`
+	AuthRequired       []string               `yaml:"authRequired"`
+	NLConfigParameters tools.Parameters       `yaml:"nlConfigParameters"`
 }
 
 // validate interface
@@ -68,6 +73,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -120,9 +129,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	cfg.NLConfigParameters = append([]tools.Parameter{newQuestionParam}, cfg.NLConfigParameters...)
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: cfg.NLConfigParameters.McpManifest(),
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  cfg.NLConfigParameters.McpManifest(),
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	t := Tool{
@@ -133,6 +145,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		NLConfig:     cfg.NLConfig,
 		AuthRequired: cfg.AuthRequired,
 		Pool:         s.PostgresPool(),
+		SessionSetup: s.PostgresSessionSetup(),
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.NLConfigParameters.Manifest(), AuthRequired: cfg.AuthRequired},
 		mcpManifest:  mcpManifest,
 	}
@@ -149,11 +162,12 @@ type Tool struct {
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 
-	Pool        *pgxpool.Pool
-	Statement   string
-	NLConfig    string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool         *pgxpool.Pool
+	SessionSetup sources.SessionSetup
+	Statement    string
+	NLConfig     string
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
@@ -165,24 +179,30 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		allParamValues[i+2] = fmt.Sprintf("%s", param)
 	}
 
-	results, err := t.Pool.Query(ctx, t.Statement, allParamValues...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w. Query: %v , Values: %v", err, t.Statement, allParamValues)
-	}
-
-	fields := results.FieldDescriptions()
-
 	var out []any
-	for results.Next() {
-		v, err := results.Values()
+	err := t.SessionSetup.RunPostgres(ctx, t.Pool, func(q sources.PostgresQuerier) error {
+		results, err := q.Query(ctx, t.Statement, allParamValues...)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return fmt.Errorf("unable to execute query: %w. Query: %v , Values: %v", err, t.Statement, allParamValues)
 		}
-		vMap := make(map[string]any)
-		for i, f := range fields {
-			vMap[f.Name] = v[i]
+
+		fields := results.FieldDescriptions()
+
+		for results.Next() {
+			v, err := results.Values()
+			if err != nil {
+				return fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, f := range fields {
+				vMap[f.Name] = v[i]
+			}
+			out = append(out, vMap)
 		}
-		out = append(out, vMap)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return out, nil
@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"google.golang.org/api/iterator"
 )
 
@@ -52,11 +53,15 @@ var _ compatibleSource = &bigqueryds.Source{}
 var compatibleSources = [...]string{bigqueryds.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name         string                 `yaml:"name" validate:"required"`
+	Kind         string                 `yaml:"kind" validate:"required"`
+	Source       string                 `yaml:"source" validate:"required"`
+	Description  string                 `yaml:"description" validate:"required"`
+	Title        string                 `yaml:"title"`
+	ResultSchema map[string]any         `yaml:"resultSchema"`
+	Annotations  *tools.ToolAnnotations `yaml:"annotations"`
+	Policy       *tools.Policy          `yaml:"policy"`
+	AuthRequired []string               `yaml:"authRequired"`
 }
 
 // validate interface
@@ -66,6 +71,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -83,9 +92,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	parameters := tools.Parameters{sqlParameter}
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: parameters.McpManifest(),
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  parameters.McpManifest(),
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations()),
 	}
 
 	// finish tool setup
@@ -124,10 +136,32 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	query := t.Client.Query(sql)
 	query.Location = t.Client.Location
 
-	it, err := query.Read(ctx)
+	job, err := query.Run(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	util.ReportProgress(ctx, 1, 2, fmt.Sprintf("started query job %s", job.ID()))
+
+	status, err := job.Wait(ctx)
+	if err != nil {
+		// the job keeps running after the invocation is cancelled, unless it
+		// is cancelled as well.
+		if ctx.Err() != nil {
+			if cancelErr := job.Cancel(context.WithoutCancel(ctx)); cancelErr != nil {
+				return nil, fmt.Errorf("unable to cancel query job %s: %w", job.ID(), cancelErr)
+			}
+		}
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	util.ReportProgress(ctx, 2, 2, fmt.Sprintf("finished query job %s", job.ID()))
+
+	it, err := job.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read query results: %w", err)
+	}
 
 	var out []any
 	for {
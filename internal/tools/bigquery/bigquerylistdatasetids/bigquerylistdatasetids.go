@@ -52,11 +52,15 @@ var _ compatibleSource = &bigqueryds.Source{}
 var compatibleSources = [...]string{bigqueryds.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name         string                 `yaml:"name" validate:"required"`
+	Kind         string                 `yaml:"kind" validate:"required"`
+	Source       string                 `yaml:"source" validate:"required"`
+	Description  string                 `yaml:"description" validate:"required"`
+	Title        string                 `yaml:"title"`
+	ResultSchema map[string]any         `yaml:"resultSchema"`
+	Annotations  *tools.ToolAnnotations `yaml:"annotations"`
+	Policy       *tools.Policy          `yaml:"policy"`
+	AuthRequired []string               `yaml:"authRequired"`
 }
 
 // validate interface
@@ -66,6 +70,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -82,9 +90,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	parameters := tools.Parameters{}
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: parameters.McpManifest(),
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  parameters.McpManifest(),
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations()),
 	}
 
 	// finish tool setup
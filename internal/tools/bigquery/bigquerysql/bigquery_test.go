@@ -111,9 +111,11 @@ func TestParseFromYamlWithTemplateBigQuery(t *testing.T) {
 						- name: tableName
 						  type: string
 						  description: The table to select hotels from.
+						  identifier: true
 						- name: fieldArray
 						  type: array
 						  description: The columns to return for the query.
+						  identifier: true
 						  items: 
 								name: column
 								type: string
@@ -130,9 +132,15 @@ func TestParseFromYamlWithTemplateBigQuery(t *testing.T) {
 					Parameters: []tools.Parameter{
 						tools.NewStringParameter("country", "some description"),
 					},
-					TemplateParameters: []tools.Parameter{
-						tools.NewStringParameter("tableName", "The table to select hotels from."),
-						tools.NewArrayParameter("fieldArray", "The columns to return for the query.", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+					TemplateParameters: tools.TemplateParameters{
+						&tools.TemplateParameter{
+							Parameter:  tools.NewStringParameter("tableName", "The table to select hotels from."),
+							Identifier: true,
+						},
+						&tools.TemplateParameter{
+							Parameter:  tools.NewArrayParameter("fieldArray", "The columns to return for the query.", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+							Identifier: true,
+						},
 					},
 				},
 			},
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
@@ -53,14 +55,18 @@ var _ compatibleSource = &bigqueryds.Source{}
 var compatibleSources = [...]string{bigqueryds.SourceKind}
 
 type Config struct {
-	Name               string           `yaml:"name" validate:"required"`
-	Kind               string           `yaml:"kind" validate:"required"`
-	Source             string           `yaml:"source" validate:"required"`
-	Description        string           `yaml:"description" validate:"required"`
-	Statement          string           `yaml:"statement" validate:"required"`
-	AuthRequired       []string         `yaml:"authRequired"`
-	Parameters         tools.Parameters `yaml:"parameters"`
-	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Name               string                   `yaml:"name" validate:"required"`
+	Kind               string                   `yaml:"kind" validate:"required"`
+	Source             string                   `yaml:"source" validate:"required"`
+	Description        string                   `yaml:"description" validate:"required"`
+	Title              string                   `yaml:"title"`
+	ResultSchema       map[string]any           `yaml:"resultSchema"`
+	Annotations        *tools.ToolAnnotations   `yaml:"annotations"`
+	Policy             *tools.Policy            `yaml:"policy"`
+	Statement          string                   `yaml:"statement" validate:"required"`
+	AuthRequired       []string                 `yaml:"authRequired"`
+	Parameters         tools.Parameters         `yaml:"parameters"`
+	TemplateParameters tools.TemplateParameters `yaml:"templateParameters"`
 }
 
 // validate interface
@@ -70,6 +76,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -83,12 +93,15 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(tools.Parameters(cfg.TemplateParameters), cfg.Parameters)
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: paramMcpManifest,
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  paramMcpManifest,
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	// finish tool setup
@@ -96,7 +109,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Name:               cfg.Name,
 		Kind:               kind,
 		Parameters:         cfg.Parameters,
-		TemplateParameters: cfg.TemplateParameters,
+		TemplateParameters: tools.Parameters(cfg.TemplateParameters),
 		AllParams:          allParameters,
 		Statement:          cfg.Statement,
 		AuthRequired:       cfg.AuthRequired,
@@ -127,7 +140,7 @@ type Tool struct {
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	namedArgs := make([]bigqueryapi.QueryParameter, 0, len(params))
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, tools.DialectGoogleSQL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -142,11 +155,27 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		switch arrayParam := value.(type) {
 		case []any:
 			var err error
-			itemType := p.McpManifest().Items.Type
+			// the MCP manifest maps dates, timestamps and bytes to strings,
+			// so the type of the items is used instead
+			var itemType string
+			if ap, ok := p.(*tools.ArrayParameter); ok {
+				itemType = ap.Items.GetType()
+			}
 			value, err = convertAnySliceToTyped(arrayParam, itemType, name)
 			if err != nil {
 				return nil, fmt.Errorf("unable to convert []any to typed slice: %w", err)
 			}
+		case nil:
+			// BigQuery needs the type of NULL values of optional parameters
+			value, err = nullValue(p)
+			if err != nil {
+				return nil, err
+			}
+		case time.Time:
+			// date parameters are parsed as time.Time, but bound as DATE
+			if p.GetType() == "date" {
+				value = civil.DateOf(arrayParam)
+			}
 		}
 
 		if strings.Contains(t.Statement, "@"+name) {
@@ -206,46 +235,105 @@ func (t Tool) Authorized(verifiedAuthServices []string) bool {
 	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
 }
 
+// nullValue returns the typed NULL value of a parameter.
+func nullValue(p tools.Parameter) (any, error) {
+	switch p.GetType() {
+	case "string":
+		return bigqueryapi.NullString{}, nil
+	case "integer":
+		return bigqueryapi.NullInt64{}, nil
+	case "float":
+		return bigqueryapi.NullFloat64{}, nil
+	case "boolean":
+		return bigqueryapi.NullBool{}, nil
+	case "date":
+		return bigqueryapi.NullDate{}, nil
+	case "timestamp":
+		return bigqueryapi.NullTimestamp{}, nil
+	case "bytes":
+		return []byte(nil), nil
+	case "array":
+		// BigQuery handles a NULL array as an empty array
+		if ap, ok := p.(*tools.ArrayParameter); ok {
+			return convertAnySliceToTyped([]any{}, ap.Items.GetType(), p.GetName())
+		}
+	}
+	return nil, fmt.Errorf("parameter '%s': NULL is not supported for type %q", p.GetName(), p.GetType())
+}
+
 func convertAnySliceToTyped(s []any, itemType, paramName string) (any, error) {
 	var typedSlice any
 	switch itemType {
 	case "string":
-		typedSlice := make([]string, len(s))
+		ts := make([]string, len(s))
 		for j, item := range s {
 			if s, ok := item.(string); ok {
-				typedSlice[j] = s
+				ts[j] = s
 			} else {
 				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be string, got %T", paramName, j, item)
 			}
 		}
+		typedSlice = ts
 	case "integer":
-		typedSlice := make([]int64, len(s))
+		ts := make([]int64, len(s))
 		for j, item := range s {
 			i, ok := item.(int)
 			if !ok {
 				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be integer, got %T", paramName, j, item)
 			}
-			typedSlice[j] = int64(i)
+			ts[j] = int64(i)
 		}
+		typedSlice = ts
 	case "float":
-		typedSlice := make([]float64, len(s))
+		ts := make([]float64, len(s))
 		for j, item := range s {
 			if f, ok := item.(float64); ok {
-				typedSlice[j] = f
+				ts[j] = f
 			} else {
 				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be float, got %T", paramName, j, item)
 			}
 		}
+		typedSlice = ts
 	case "boolean":
-		typedSlice := make([]bool, len(s))
+		ts := make([]bool, len(s))
 		for j, item := range s {
 			if b, ok := item.(bool); ok {
-				typedSlice[j] = b
+				ts[j] = b
 			} else {
 				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be boolean, got %T", paramName, j, item)
 			}
 		}
-
+		typedSlice = ts
+	case "date":
+		ts := make([]civil.Date, len(s))
+		for j, item := range s {
+			if d, ok := item.(time.Time); ok {
+				ts[j] = civil.DateOf(d)
+			} else {
+				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be date, got %T", paramName, j, item)
+			}
+		}
+		typedSlice = ts
+	case "timestamp":
+		ts := make([]time.Time, len(s))
+		for j, item := range s {
+			if t, ok := item.(time.Time); ok {
+				ts[j] = t
+			} else {
+				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be timestamp, got %T", paramName, j, item)
+			}
+		}
+		typedSlice = ts
+	case "bytes":
+		ts := make([][]byte, len(s))
+		for j, item := range s {
+			if b, ok := item.([]byte); ok {
+				ts[j] = b
+			} else {
+				return nil, fmt.Errorf("parameter '%s': expected item at index %d to be bytes, got %T", paramName, j, item)
+			}
+		}
+		typedSlice = ts
 	}
 	return typedSlice, nil
 }
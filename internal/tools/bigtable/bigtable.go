@@ -17,8 +17,10 @@ package bigtable
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/civil"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigtabledb "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
@@ -51,14 +53,18 @@ var _ compatibleSource = &bigtabledb.Source{}
 var compatibleSources = [...]string{bigtabledb.SourceKind}
 
 type Config struct {
-	Name               string           `yaml:"name" validate:"required"`
-	Kind               string           `yaml:"kind" validate:"required"`
-	Source             string           `yaml:"source" validate:"required"`
-	Description        string           `yaml:"description" validate:"required"`
-	Statement          string           `yaml:"statement" validate:"required"`
-	AuthRequired       []string         `yaml:"authRequired"`
-	Parameters         tools.Parameters `yaml:"parameters"`
-	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Name               string                   `yaml:"name" validate:"required"`
+	Kind               string                   `yaml:"kind" validate:"required"`
+	Source             string                   `yaml:"source" validate:"required"`
+	Description        string                   `yaml:"description" validate:"required"`
+	Title              string                   `yaml:"title"`
+	ResultSchema       map[string]any           `yaml:"resultSchema"`
+	Annotations        *tools.ToolAnnotations   `yaml:"annotations"`
+	Policy             *tools.Policy            `yaml:"policy"`
+	Statement          string                   `yaml:"statement" validate:"required"`
+	AuthRequired       []string                 `yaml:"authRequired"`
+	Parameters         tools.Parameters         `yaml:"parameters"`
+	TemplateParameters tools.TemplateParameters `yaml:"templateParameters"`
 }
 
 // validate interface
@@ -68,6 +74,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -81,12 +91,15 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(tools.Parameters(cfg.TemplateParameters), cfg.Parameters)
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: paramMcpManifest,
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  paramMcpManifest,
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	// finish tool setup
@@ -94,7 +107,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Name:               cfg.Name,
 		Kind:               kind,
 		Parameters:         cfg.Parameters,
-		TemplateParameters: cfg.TemplateParameters,
+		TemplateParameters: tools.Parameters(cfg.TemplateParameters),
 		AllParams:          allParameters,
 		Statement:          cfg.Statement,
 		AuthRequired:       cfg.AuthRequired,
@@ -141,15 +154,34 @@ func getMapParamsType(tparams tools.Parameters, params tools.ParamValues) (map[s
 			btParams[p.Name] = bigtable.Float64SQLType{}
 		case "array":
 			btParams[p.Name] = bigtable.ArraySQLType{}
+		case "date":
+			btParams[p.Name] = bigtable.DateSQLType{}
+		case "timestamp":
+			btParams[p.Name] = bigtable.TimestampSQLType{}
+		case "bytes":
+			btParams[p.Name] = bigtable.BytesSQLType{}
 		}
 	}
 
 	return btParams, nil
 }
 
+// getBindValues returns the values of the params, converted to the types
+// expected by Bigtable.
+func getBindValues(tparams tools.Parameters, params tools.ParamValues) map[string]any {
+	values := params.AsMap()
+	for _, p := range tparams {
+		// date parameters are parsed as time.Time, but bound as civil.Date
+		if d, ok := values[p.GetName()].(time.Time); ok && p.GetType() == "date" {
+			values[p.GetName()] = civil.DateOf(d)
+		}
+	}
+	return values
+}
+
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, tools.DialectGoogleSQL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -173,7 +205,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		return nil, fmt.Errorf("unable to prepare statement: %w", err)
 	}
 
-	bs, err := ps.Bind(newParams.AsMap())
+	bs, err := ps.Bind(getBindValues(t.Parameters, newParams))
 	if err != nil {
 		return nil, fmt.Errorf("unable to bind: %w", err)
 	}
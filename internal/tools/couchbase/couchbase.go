@@ -53,14 +53,18 @@ var _ compatibleSource = &couchbase.Source{}
 var compatibleSources = [...]string{couchbase.SourceKind}
 
 type Config struct {
-	Name               string           `yaml:"name" validate:"required"`
-	Kind               string           `yaml:"kind" validate:"required"`
-	Source             string           `yaml:"source" validate:"required"`
-	Description        string           `yaml:"description" validate:"required"`
-	Statement          string           `yaml:"statement" validate:"required"`
-	AuthRequired       []string         `yaml:"authRequired"`
-	Parameters         tools.Parameters `yaml:"parameters"`
-	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Name               string                   `yaml:"name" validate:"required"`
+	Kind               string                   `yaml:"kind" validate:"required"`
+	Source             string                   `yaml:"source" validate:"required"`
+	Description        string                   `yaml:"description" validate:"required"`
+	Title              string                   `yaml:"title"`
+	ResultSchema       map[string]any           `yaml:"resultSchema"`
+	Annotations        *tools.ToolAnnotations   `yaml:"annotations"`
+	Policy             *tools.Policy            `yaml:"policy"`
+	Statement          string                   `yaml:"statement" validate:"required"`
+	AuthRequired       []string                 `yaml:"authRequired"`
+	Parameters         tools.Parameters         `yaml:"parameters"`
+	TemplateParameters tools.TemplateParameters `yaml:"templateParameters"`
 }
 
 // validate interface
@@ -70,6 +74,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -83,19 +91,22 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(tools.Parameters(cfg.TemplateParameters), cfg.Parameters)
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: paramMcpManifest,
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  paramMcpManifest,
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 	// finish tool setup
 	t := Tool{
 		Name:                 cfg.Name,
 		Kind:                 kind,
 		Parameters:           cfg.Parameters,
-		TemplateParameters:   cfg.TemplateParameters,
+		TemplateParameters:   tools.Parameters(cfg.TemplateParameters),
 		AllParams:            allParameters,
 		Statement:            cfg.Statement,
 		Scope:                s.CouchbaseScope(),
@@ -127,7 +138,7 @@ type Tool struct {
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	namedParamsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, namedParamsMap)
+	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, namedParamsMap, tools.DialectSQLPlusPlus)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
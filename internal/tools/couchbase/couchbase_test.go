@@ -114,6 +114,7 @@ func TestParseFromYamlWithTemplateMssql(t *testing.T) {
 						- name: tableName
 						  type: string
 						  description: The table to select hotels from.
+						  identifier: true
 			`,
 			want: server.ToolConfigs{
 				"example_tool": couchbase.Config{
@@ -126,8 +127,11 @@ func TestParseFromYamlWithTemplateMssql(t *testing.T) {
 					Parameters: []tools.Parameter{
 						tools.NewStringParameter("hotel", "hotel parameter description"),
 					},
-					TemplateParameters: []tools.Parameter{
-						tools.NewStringParameter("tableName", "The table to select hotels from."),
+					TemplateParameters: tools.TemplateParameters{
+						&tools.TemplateParameter{
+							Parameter:  tools.NewStringParameter("tableName", "The table to select hotels from."),
+							Identifier: true,
+						},
 					},
 				},
 			},
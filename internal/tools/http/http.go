@@ -16,6 +16,7 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +27,7 @@ import (
 
 	"maps"
 	"text/template"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -50,19 +52,25 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type Config struct {
-	Name         string            `yaml:"name" validate:"required"`
-	Kind         string            `yaml:"kind" validate:"required"`
-	Source       string            `yaml:"source" validate:"required"`
-	Description  string            `yaml:"description" validate:"required"`
-	AuthRequired []string          `yaml:"authRequired"`
-	Path         string            `yaml:"path" validate:"required"`
-	Method       tools.HTTPMethod  `yaml:"method" validate:"required"`
-	Headers      map[string]string `yaml:"headers"`
-	RequestBody  string            `yaml:"requestBody"`
-	PathParams   tools.Parameters  `yaml:"pathParams"`
-	QueryParams  tools.Parameters  `yaml:"queryParams"`
-	BodyParams   tools.Parameters  `yaml:"bodyParams"`
-	HeaderParams tools.Parameters  `yaml:"headerParams"`
+	Name         string                 `yaml:"name" validate:"required"`
+	Kind         string                 `yaml:"kind" validate:"required"`
+	Source       string                 `yaml:"source" validate:"required"`
+	Description  string                 `yaml:"description" validate:"required"`
+	Title        string                 `yaml:"title"`
+	ResultSchema map[string]any         `yaml:"resultSchema"`
+	Annotations  *tools.ToolAnnotations `yaml:"annotations"`
+	Policy       *tools.Policy          `yaml:"policy"`
+	AuthRequired []string               `yaml:"authRequired"`
+	Path         string                 `yaml:"path" validate:"required"`
+	Method       tools.HTTPMethod       `yaml:"method" validate:"required"`
+	Headers      map[string]string      `yaml:"headers"`
+	RequestBody  string                 `yaml:"requestBody"`
+	PathParams   tools.Parameters       `yaml:"pathParams"`
+	QueryParams  tools.Parameters       `yaml:"queryParams"`
+	BodyParams   tools.Parameters       `yaml:"bodyParams"`
+	HeaderParams tools.Parameters       `yaml:"headerParams"`
+	// ForwardAuth overrides the forwardAuth of the source.
+	ForwardAuth *httpsrc.ForwardAuthConfig `yaml:"forwardAuth"`
 }
 
 // validate interface
@@ -72,6 +80,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -151,10 +163,22 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		seenNames[param.Name] = true
 	}
 
+	forwarder := s.Forwarder
+	if cfg.ForwardAuth != nil {
+		var err error
+		forwarder, err = cfg.ForwardAuth.Initialize(s.BaseClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forwardAuth for tool %q: %w", cfg.Name, err)
+		}
+	}
+
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: paramMcpManifest,
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  paramMcpManifest,
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	// finish tool setup
@@ -173,6 +197,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Headers:            combinedHeaders,
 		DefaultQueryParams: s.QueryParams,
 		Client:             s.Client,
+		Forwarder:          forwarder,
 		AllParams:          allParameters,
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
@@ -202,6 +227,7 @@ type Tool struct {
 	AllParams    tools.Parameters `yaml:"allParams"`
 
 	Client      *http.Client
+	Forwarder   *httpsrc.TokenForwarder
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -275,12 +301,32 @@ func getURL(baseURL, path string, pathParams, queryParams tools.Parameters, defa
 	// Set dynamic query parameters
 	query := parsedURL.Query()
 	for _, p := range queryParams {
-		query.Add(p.GetName(), fmt.Sprintf("%v", paramsMap[p.GetName()]))
+		v := paramsMap[p.GetName()]
+		// optional query parameters without a value are left out
+		if v == nil {
+			continue
+		}
+		query.Add(p.GetName(), queryValue(p, v))
 	}
 	parsedURL.RawQuery = query.Encode()
 	return parsedURL.String(), nil
 }
 
+// queryValue formats the value of a query parameter. Dates, timestamps and
+// bytes are formatted like the values that were sent to the tool.
+func queryValue(p tools.Parameter, v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		if p.GetType() == "date" {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
 // Helper function to generate the HTTP headers upon Tool invocation.
 func getHeaders(headerParams tools.Parameters, defaultHeaders map[string]string, paramsMap map[string]any) (map[string]string, error) {
 	// Populate header params
@@ -288,7 +334,7 @@ func getHeaders(headerParams tools.Parameters, defaultHeaders map[string]string,
 	maps.Copy(allHeaders, defaultHeaders)
 	for _, p := range headerParams {
 		headerValue, ok := paramsMap[p.GetName()]
-		if ok {
+		if ok && headerValue != nil {
 			if strValue, ok := headerValue.(string); ok {
 				allHeaders[p.GetName()] = strValue
 			} else {
@@ -314,7 +360,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		return nil, fmt.Errorf("error populating path parameters: %s", err)
 	}
 
-	req, _ := http.NewRequest(string(t.Method), urlString, strings.NewReader(requestBody))
+	req, _ := http.NewRequestWithContext(ctx, string(t.Method), urlString, strings.NewReader(requestBody))
 
 	// Calculate request headers
 	allHeaders, err := getHeaders(t.HeaderParams, t.Headers, paramsMap)
@@ -325,6 +371,15 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	for k, v := range allHeaders {
 		req.Header.Set(k, v)
 	}
+	// The forwarded token of the caller takes precedence over a static
+	// `Authorization` header
+	if t.Forwarder != nil {
+		token, err := t.Forwarder.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get token to forward: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	// Make request and fetch response
 	resp, err := t.Client.Do(req)
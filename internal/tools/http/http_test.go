@@ -21,6 +21,7 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	http "github.com/googleapis/genai-toolbox/internal/tools/http"
@@ -137,6 +138,34 @@ func TestParseFromYamlHTTP(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "forward auth example",
+			in: `
+			tools:
+				example_tool:
+					kind: http
+					source: my-instance
+					method: GET
+					description: some description
+					path: search
+					authRequired:
+						- my-oidc
+					forwardAuth:
+						authService: my-oidc
+				`,
+			want: server.ToolConfigs{
+				"example_tool": http.Config{
+					Name:         "example_tool",
+					Kind:         "http",
+					Source:       "my-instance",
+					Method:       "GET",
+					Path:         "search",
+					Description:  "some description",
+					AuthRequired: []string{"my-oidc"},
+					ForwardAuth:  &httpsrc.ForwardAuthConfig{AuthService: "my-oidc"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
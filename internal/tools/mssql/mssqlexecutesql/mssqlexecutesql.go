@@ -44,6 +44,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 
 type compatibleSource interface {
 	MSSQLDB() *sql.DB
+	MSSQLSessionSetup() sources.SessionSetup
 }
 
 // validate compatible sources are still compatible
@@ -53,11 +54,15 @@ var _ compatibleSource = &mssql.Source{}
 var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name         string                 `yaml:"name" validate:"required"`
+	Kind         string                 `yaml:"kind" validate:"required"`
+	Source       string                 `yaml:"source" validate:"required"`
+	Description  string                 `yaml:"description" validate:"required"`
+	Title        string                 `yaml:"title"`
+	ResultSchema map[string]any         `yaml:"resultSchema"`
+	Annotations  *tools.ToolAnnotations `yaml:"annotations"`
+	Policy       *tools.Policy          `yaml:"policy"`
+	AuthRequired []string               `yaml:"authRequired"`
 }
 
 // validate interface
@@ -67,6 +72,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -84,9 +93,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	parameters := tools.Parameters{sqlParameter}
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: parameters.McpManifest(),
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  parameters.McpManifest(),
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations()),
 	}
 
 	// finish tool setup
@@ -96,6 +108,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:   parameters,
 		AuthRequired: cfg.AuthRequired,
 		Pool:         s.MSSQLDB(),
+		SessionSetup: s.MSSQLSessionSetup(),
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
 		mcpManifest:  mcpManifest,
 	}
@@ -111,9 +124,10 @@ type Tool struct {
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 
-	Pool        *sql.DB
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool         *sql.DB
+	SessionSetup sources.SessionSetup
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
@@ -122,43 +136,49 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
-	results, err := t.Pool.QueryContext(ctx, sql)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
-	}
-	defer results.Close()
-
-	cols, err := results.Columns()
-	// If Columns() errors, it might be a DDL/DML without an OUTPUT clause.
-	// We proceed, and results.Err() will catch actual query execution errors.
-	// 'out' will remain nil if cols is empty or err is not nil here.
-
 	var out []any
-	if err == nil && len(cols) > 0 {
-		// create an array of values for each column, which can be re-used to scan each row
-		rawValues := make([]any, len(cols))
-		values := make([]any, len(cols))
-		for i := range rawValues {
-			values[i] = &rawValues[i]
+	err := t.SessionSetup.RunSQL(ctx, t.Pool, func(q sources.SQLQuerier) error {
+		results, err := q.QueryContext(ctx, sql)
+		if err != nil {
+			return fmt.Errorf("unable to execute query: %w", err)
 		}
-
-		for results.Next() {
-			scanErr := results.Scan(values...)
-			if scanErr != nil {
-				return nil, fmt.Errorf("unable to parse row: %w", scanErr)
+		defer results.Close()
+
+		cols, err := results.Columns()
+		// If Columns() errors, it might be a DDL/DML without an OUTPUT clause.
+		// We proceed, and results.Err() will catch actual query execution errors.
+		// 'out' will remain nil if cols is empty or err is not nil here.
+
+		if err == nil && len(cols) > 0 {
+			// create an array of values for each column, which can be re-used to scan each row
+			rawValues := make([]any, len(cols))
+			values := make([]any, len(cols))
+			for i := range rawValues {
+				values[i] = &rawValues[i]
 			}
-			vMap := make(map[string]any)
-			for i, name := range cols {
-				vMap[name] = rawValues[i]
+
+			for results.Next() {
+				scanErr := results.Scan(values...)
+				if scanErr != nil {
+					return fmt.Errorf("unable to parse row: %w", scanErr)
+				}
+				vMap := make(map[string]any)
+				for i, name := range cols {
+					vMap[name] = rawValues[i]
+				}
+				out = append(out, vMap)
 			}
-			out = append(out, vMap)
 		}
-	}
 
-	// Check for errors from iterating over rows or from the query execution itself.
-	// results.Close() is handled by defer.
-	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("errors encountered during query execution or row processing: %w", err)
+		// Check for errors from iterating over rows or from the query execution itself.
+		// results.Close() is handled by defer.
+		if err := results.Err(); err != nil {
+			return fmt.Errorf("errors encountered during query execution or row processing: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return out, nil
@@ -45,6 +45,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 
 type compatibleSource interface {
 	MSSQLDB() *sql.DB
+	MSSQLSessionSetup() sources.SessionSetup
 }
 
 // validate compatible sources are still compatible
@@ -54,14 +55,18 @@ var _ compatibleSource = &mssql.Source{}
 var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
-	Name               string           `yaml:"name" validate:"required"`
-	Kind               string           `yaml:"kind" validate:"required"`
-	Source             string           `yaml:"source" validate:"required"`
-	Description        string           `yaml:"description" validate:"required"`
-	Statement          string           `yaml:"statement" validate:"required"`
-	AuthRequired       []string         `yaml:"authRequired"`
-	Parameters         tools.Parameters `yaml:"parameters"`
-	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Name               string                   `yaml:"name" validate:"required"`
+	Kind               string                   `yaml:"kind" validate:"required"`
+	Source             string                   `yaml:"source" validate:"required"`
+	Description        string                   `yaml:"description" validate:"required"`
+	Title              string                   `yaml:"title"`
+	ResultSchema       map[string]any           `yaml:"resultSchema"`
+	Annotations        *tools.ToolAnnotations   `yaml:"annotations"`
+	Policy             *tools.Policy            `yaml:"policy"`
+	Statement          string                   `yaml:"statement" validate:"required"`
+	AuthRequired       []string                 `yaml:"authRequired"`
+	Parameters         tools.Parameters         `yaml:"parameters"`
+	TemplateParameters tools.TemplateParameters `yaml:"templateParameters"`
 }
 
 // validate interface
@@ -71,6 +76,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -84,12 +93,15 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(tools.Parameters(cfg.TemplateParameters), cfg.Parameters)
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: paramMcpManifest,
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  paramMcpManifest,
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	// finish tool setup
@@ -97,11 +109,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Name:               cfg.Name,
 		Kind:               kind,
 		Parameters:         cfg.Parameters,
-		TemplateParameters: cfg.TemplateParameters,
+		TemplateParameters: tools.Parameters(cfg.TemplateParameters),
 		AllParams:          allParameters,
 		Statement:          cfg.Statement,
 		AuthRequired:       cfg.AuthRequired,
 		Db:                 s.MSSQLDB(),
+		SessionSetup:       s.MSSQLSessionSetup(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}
@@ -119,15 +132,16 @@ type Tool struct {
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
 
-	Db          *sql.DB
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Db           *sql.DB
+	SessionSetup sources.SessionSetup
+	Statement    string
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, tools.DialectMSSQL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -150,42 +164,48 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		}
 	}
 
-	rows, err := t.Db.QueryContext(ctx, newStatement, namedArgs...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
-	}
+	var out []any
+	err = t.SessionSetup.RunSQL(ctx, t.Db, func(q sources.SQLQuerier) error {
+		rows, err := q.QueryContext(ctx, newStatement, namedArgs...)
+		if err != nil {
+			return fmt.Errorf("unable to execute query: %w", err)
+		}
 
-	cols, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch column types: %w", err)
-	}
+		cols, err := rows.Columns()
+		if err != nil {
+			return fmt.Errorf("unable to fetch column types: %w", err)
+		}
 
-	// create an array of values for each column, which can be re-used to scan each row
-	rawValues := make([]any, len(cols))
-	values := make([]any, len(cols))
-	for i := range rawValues {
-		values[i] = &rawValues[i]
-	}
+		// create an array of values for each column, which can be re-used to scan each row
+		rawValues := make([]any, len(cols))
+		values := make([]any, len(cols))
+		for i := range rawValues {
+			values[i] = &rawValues[i]
+		}
 
-	var out []any
-	for rows.Next() {
-		err = rows.Scan(values...)
+		for rows.Next() {
+			err = rows.Scan(values...)
+			if err != nil {
+				return fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, name := range cols {
+				vMap[name] = rawValues[i]
+			}
+			out = append(out, vMap)
+		}
+		err = rows.Close()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return fmt.Errorf("unable to close rows: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, name := range cols {
-			vMap[name] = rawValues[i]
+
+		// Check if error occurred during iteration
+		if err := rows.Err(); err != nil {
+			return err
 		}
-		out = append(out, vMap)
-	}
-	err = rows.Close()
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to close rows: %w", err)
-	}
-
-	// Check if error occurred during iteration
-	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
@@ -44,6 +44,7 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 
 type compatibleSource interface {
 	MySQLPool() *sql.DB
+	MySQLSessionSetup() sources.SessionSetup
 }
 
 // validate compatible sources are still compatible
@@ -53,14 +54,18 @@ var _ compatibleSource = &mysql.Source{}
 var compatibleSources = [...]string{cloudsqlmysql.SourceKind, mysql.SourceKind}
 
 type Config struct {
-	Name               string           `yaml:"name" validate:"required"`
-	Kind               string           `yaml:"kind" validate:"required"`
-	Source             string           `yaml:"source" validate:"required"`
-	Description        string           `yaml:"description" validate:"required"`
-	Statement          string           `yaml:"statement" validate:"required"`
-	AuthRequired       []string         `yaml:"authRequired"`
-	Parameters         tools.Parameters `yaml:"parameters"`
-	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Name               string                   `yaml:"name" validate:"required"`
+	Kind               string                   `yaml:"kind" validate:"required"`
+	Source             string                   `yaml:"source" validate:"required"`
+	Description        string                   `yaml:"description" validate:"required"`
+	Title              string                   `yaml:"title"`
+	ResultSchema       map[string]any           `yaml:"resultSchema"`
+	Annotations        *tools.ToolAnnotations   `yaml:"annotations"`
+	Policy             *tools.Policy            `yaml:"policy"`
+	Statement          string                   `yaml:"statement" validate:"required"`
+	AuthRequired       []string                 `yaml:"authRequired"`
+	Parameters         tools.Parameters         `yaml:"parameters"`
+	TemplateParameters tools.TemplateParameters `yaml:"templateParameters"`
 }
 
 // validate interface
@@ -70,6 +75,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -83,12 +92,15 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(tools.Parameters(cfg.TemplateParameters), cfg.Parameters)
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: paramMcpManifest,
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  paramMcpManifest,
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	// finish tool setup
@@ -96,11 +108,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Name:               cfg.Name,
 		Kind:               kind,
 		Parameters:         cfg.Parameters,
-		TemplateParameters: cfg.TemplateParameters,
+		TemplateParameters: tools.Parameters(cfg.TemplateParameters),
 		AllParams:          allParameters,
 		Statement:          cfg.Statement,
 		AuthRequired:       cfg.AuthRequired,
 		Pool:               s.MySQLPool(),
+		SessionSetup:       s.MySQLSessionSetup(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}
@@ -118,15 +131,16 @@ type Tool struct {
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
 
-	Pool        *sql.DB
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool         *sql.DB
+	SessionSetup sources.SessionSetup
+	Statement    string
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	paramsMap := params.AsMap()
-	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
+	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap, tools.DialectMySQL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
 	}
@@ -137,57 +151,63 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	}
 
 	sliceParams := newParams.AsSlice()
-	results, err := t.Pool.QueryContext(ctx, newStatement, sliceParams...)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
-	}
-
-	cols, err := results.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
-	}
+	var out []any
+	err = t.SessionSetup.RunSQL(ctx, t.Pool, func(q sources.SQLQuerier) error {
+		results, err := q.QueryContext(ctx, newStatement, sliceParams...)
+		if err != nil {
+			return fmt.Errorf("unable to execute query: %w", err)
+		}
 
-	// create an array of values for each column, which can be re-used to scan each row
-	rawValues := make([]any, len(cols))
-	values := make([]any, len(cols))
-	for i := range rawValues {
-		values[i] = &rawValues[i]
-	}
-	defer results.Close()
+		cols, err := results.Columns()
+		if err != nil {
+			return fmt.Errorf("unable to retrieve rows column name: %w", err)
+		}
 
-	colTypes, err := results.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("unable to get column types: %w", err)
-	}
+		// create an array of values for each column, which can be re-used to scan each row
+		rawValues := make([]any, len(cols))
+		values := make([]any, len(cols))
+		for i := range rawValues {
+			values[i] = &rawValues[i]
+		}
+		defer results.Close()
 
-	var out []any
-	for results.Next() {
-		err := results.Scan(values...)
+		colTypes, err := results.ColumnTypes()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return fmt.Errorf("unable to get column types: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, name := range cols {
-			val := rawValues[i]
-			if val == nil {
-				vMap[name] = nil
-				continue
-			}
 
-			// mysql driver return []uint8 type for "TEXT", "VARCHAR", and "NVARCHAR"
-			// we'll need to cast it back to string
-			switch colTypes[i].DatabaseTypeName() {
-			case "TEXT", "VARCHAR", "NVARCHAR":
-				vMap[name] = string(val.([]byte))
-			default:
-				vMap[name] = val
+		for results.Next() {
+			err := results.Scan(values...)
+			if err != nil {
+				return fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, name := range cols {
+				val := rawValues[i]
+				if val == nil {
+					vMap[name] = nil
+					continue
+				}
+
+				// mysql driver return []uint8 type for "TEXT", "VARCHAR", and "NVARCHAR"
+				// we'll need to cast it back to string
+				switch colTypes[i].DatabaseTypeName() {
+				case "TEXT", "VARCHAR", "NVARCHAR":
+					vMap[name] = string(val.([]byte))
+				default:
+					vMap[name] = val
+				}
 			}
+			out = append(out, vMap)
 		}
-		out = append(out, vMap)
-	}
 
-	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
+		if err := results.Err(); err != nil {
+			return fmt.Errorf("errors encountered during row iteration: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return out, nil
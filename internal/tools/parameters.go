@@ -17,11 +17,18 @@ package tools
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/mail"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
+	"unicode/utf8"
 
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
@@ -32,6 +39,12 @@ const (
 	typeFloat  = "float"
 	typeBool   = "boolean"
 	typeArray  = "array"
+	typeObject = "object"
+	// date, timestamp and bytes values are sent as strings, and parsed as
+	// time.Time or []byte
+	typeDate      = "date"
+	typeTimestamp = "timestamp"
+	typeBytes     = "bytes"
 )
 
 // ParamValues is an ordered list of ParamValue
@@ -117,14 +130,21 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		paramAuthServices := p.GetAuthServices()
 		name := p.GetName()
 		if len(paramAuthServices) == 0 {
-			// parse non auth-required parameter
+			// parse non auth-required parameter, only a missing value
+			// falls back to the default
 			var ok bool
 			v, ok = data[name]
 			if !ok {
 				v = p.GetDefault()
-				if v == nil {
+			}
+			if v == nil {
+				if p.GetRequired() {
 					return nil, fmt.Errorf("parameter %q is required", name)
 				}
+				// optional parameters that are missing or explicitly null
+				// are passed as nil, e.g. NULL in SQL statements
+				params = append(params, ParamValue{Name: name, Value: nil})
+				continue
 			}
 		} else {
 			// parse authenticated parameter
@@ -143,6 +163,37 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 	return params, nil
 }
 
+// ConvertStringToParamValue converts a string value into the representation
+// expected by Parse for a parameter of the given type. This is used where
+// clients can only send strings, such as URI template variables and MCP prompt
+// arguments.
+func ConvertStringToParamValue(paramType string, v string) (any, error) {
+	switch paramType {
+	case typeInt, typeFloat:
+		return json.Number(v), nil
+	case typeBool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q not type %q", v, paramType)
+		}
+		return b, nil
+	case typeArray:
+		var arr []any
+		if err := util.DecodeJSON(strings.NewReader(v), &arr); err != nil {
+			return nil, fmt.Errorf("%q not type %q", v, paramType)
+		}
+		return arr, nil
+	case typeObject:
+		var obj map[string]any
+		if err := util.DecodeJSON(strings.NewReader(v), &obj); err != nil {
+			return nil, fmt.Errorf("%q not type %q", v, paramType)
+		}
+		return obj, nil
+	default:
+		return v, nil
+	}
+}
+
 // helper function to convert a string array parameter to a comma separated string
 func ConvertArrayParamToString(param any) (string, error) {
 	switch v := param.(type) {
@@ -175,12 +226,22 @@ func GetParams(params Parameters, paramValuesMap map[string]any) (ParamValues, e
 	return resultParamValues, nil
 }
 
-func ResolveTemplateParams(templateParams Parameters, originalStatement string, paramsMap map[string]any) (string, error) {
+// ResolveTemplateParams inserts the values of the template parameters into the
+// statement. String values are validated, and identifiers are quoted for
+// dialect.
+func ResolveTemplateParams(templateParams Parameters, originalStatement string, paramsMap map[string]any, dialect Dialect) (string, error) {
 	templateParamsValues, err := GetParams(templateParams, paramsMap)
-	templateParamsMap := templateParamsValues.AsMap()
 	if err != nil {
 		return "", fmt.Errorf("error getting template params %s", err)
 	}
+	for i, p := range templateParams {
+		v, err := templateValue(p, templateParamsValues[i].Value, dialect)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for template parameter %q: %w", p.GetName(), err)
+		}
+		templateParamsValues[i].Value = v
+	}
+	templateParamsMap := templateParamsValues.AsMap()
 
 	funcMap := template.FuncMap{
 		"array": ConvertArrayParamToString,
@@ -248,6 +309,7 @@ type Parameter interface {
 	GetName() string
 	GetType() string
 	GetDefault() any
+	GetRequired() bool
 	GetAuthServices() []ParamAuthService
 	Parse(any) (any, error)
 	Manifest() ParameterManifest
@@ -308,6 +370,9 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := a.validateConstraints(); err != nil {
+			return nil, fmt.Errorf("invalid constraints for %q: %w", a.Name, err)
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -319,6 +384,9 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := a.validateConstraints(); err != nil {
+			return nil, fmt.Errorf("invalid constraints for %q: %w", a.Name, err)
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -330,6 +398,9 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := a.validateConstraints(); err != nil {
+			return nil, fmt.Errorf("invalid constraints for %q: %w", a.Name, err)
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -347,11 +418,58 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 			a.AuthSources = nil
 		}
 		return a, nil
+	case typeDate:
+		a := &DateParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case typeTimestamp:
+		a := &TimestampParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case typeBytes:
+		a := &BytesParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
 	case typeArray:
 		a := &ArrayParameter{}
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := a.validateConstraints(); err != nil {
+			return nil, fmt.Errorf("invalid constraints for %q: %w", a.Name, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		return a, nil
+	case typeObject:
+		a := &ObjectParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -377,8 +495,7 @@ func (ps Parameters) McpManifest() McpToolsSchema {
 	for _, p := range ps {
 		name := p.GetName()
 		properties[name] = p.McpManifest()
-		// parameters that doesn't have a default value are added to the required field
-		if p.GetDefault() == nil {
+		if p.GetRequired() {
 			required = append(required, name)
 		}
 	}
@@ -398,6 +515,10 @@ type ParameterManifest struct {
 	Description  string             `json:"description"`
 	AuthServices []string           `json:"authSources"`
 	Items        *ParameterManifest `json:"items,omitempty"`
+	// Properties and AdditionalProperties are only set for objects.
+	// AdditionalProperties is either a bool or a *ParameterManifest.
+	Properties           []ParameterManifest `json:"properties,omitempty"`
+	AdditionalProperties any                 `json:"additionalProperties,omitempty"`
 }
 
 // ParameterMcpManifest represents properties when served as part of a ToolMcpManifest.
@@ -405,6 +526,24 @@ type ParameterMcpManifest struct {
 	Type        string                `json:"type"`
 	Description string                `json:"description"`
 	Items       *ParameterMcpManifest `json:"items,omitempty"`
+	// constraints on the value of the parameter
+	Enum      []any    `json:"enum,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	// ContentEncoding is set for bytes, which are sent as base64 strings
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	MinItems        *int   `json:"minItems,omitempty"`
+	MaxItems        *int   `json:"maxItems,omitempty"`
+	// Properties, Required and AdditionalProperties are only set for
+	// objects. AdditionalProperties is either a bool or a
+	// *ParameterMcpManifest.
+	Properties           map[string]ParameterMcpManifest `json:"properties,omitempty"`
+	Required             []string                        `json:"required,omitempty"`
+	AdditionalProperties any                             `json:"additionalProperties,omitempty"`
 }
 
 // CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
@@ -412,6 +551,7 @@ type CommonParameter struct {
 	Name         string             `yaml:"name" validate:"required"`
 	Type         string             `yaml:"type" validate:"required"`
 	Default      any                `yaml:"default"`
+	Required     *bool              `yaml:"required"`
 	Desc         string             `yaml:"description" validate:"required"`
 	AuthServices []ParamAuthService `yaml:"authServices"`
 	AuthSources  []ParamAuthService `yaml:"authSources"` // Deprecated: Kept for compatibility.
@@ -431,6 +571,15 @@ func (p *CommonParameter) GetDefault() any {
 	return p.Default
 }
 
+// GetRequired returns true if a value must be set for the Parameter. Unless
+// `required` is set, parameters without a default value are required.
+func (p *CommonParameter) GetRequired() bool {
+	if p.Required != nil {
+		return *p.Required
+	}
+	return p.Default == nil
+}
+
 // Manifest returns the manifest for the Parameter.
 func (p *CommonParameter) Manifest() ParameterManifest {
 	// only list ParamAuthService names (without fields) in manifest
@@ -438,14 +587,10 @@ func (p *CommonParameter) Manifest() ParameterManifest {
 	for i, a := range p.AuthServices {
 		authNames[i] = a.Name
 	}
-	var required bool
-	if p.Default == nil {
-		required = true
-	}
 	return ParameterManifest{
 		Name:         p.Name,
 		Type:         p.Type,
-		Required:     required,
+		Required:     p.GetRequired(),
 		Description:  p.Desc,
 		AuthServices: authNames,
 	}
@@ -470,6 +615,104 @@ func (e ParseTypeError) Error() string {
 	return fmt.Sprintf("%q not type %q", e.Value, e.Type)
 }
 
+// ConstraintError is returned when the value of a parameter violates one of
+// its constraints, such as "maxLength".
+type ConstraintError struct {
+	Constraint string
+	Message    string
+}
+
+func (e ConstraintError) Error() string {
+	return fmt.Sprintf("violates %q constraint: %s", e.Constraint, e.Message)
+}
+
+func newConstraintError(constraint string, format string, args ...any) *ConstraintError {
+	return &ConstraintError{Constraint: constraint, Message: fmt.Sprintf(format, args...)}
+}
+
+const (
+	formatDate     = "date"
+	formatDateTime = "date-time"
+	formatUUID     = "uuid"
+	formatEmail    = "email"
+)
+
+var formats = []string{formatDate, formatDateTime, formatUUID, formatEmail}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkFormat returns an error if v is not formatted as format.
+func checkFormat(format, v string) error {
+	var ok bool
+	switch format {
+	case formatDate:
+		_, err := time.Parse(time.DateOnly, v)
+		ok = err == nil
+	case formatDateTime:
+		_, err := time.Parse(time.RFC3339, v)
+		ok = err == nil
+	case formatUUID:
+		ok = uuidRegexp.MatchString(v)
+	case formatEmail:
+		addr, err := mail.ParseAddress(v)
+		ok = err == nil && addr.Address == v
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if !ok {
+		return newConstraintError("format", "%q is not a valid %s", v, format)
+	}
+	return nil
+}
+
+// patternCache caches the compiled regular expressions of "pattern"
+// constraints, since they are checked on every invocation.
+var patternCache sync.Map
+
+// compilePattern compiles a "pattern" constraint. Like in JSON Schema, the
+// pattern isn't anchored.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
+
+// checkRange returns an error if the minimum of a constraint is greater than
+// its maximum.
+func checkRange[T int | float64](minName string, minV *T, maxName string, maxV *T) error {
+	if minV != nil && maxV != nil && *minV > *maxV {
+		return fmt.Errorf("%s %v is greater than %s %v", minName, *minV, maxName, *maxV)
+	}
+	return nil
+}
+
+// floatPtr converts a number constraint for the MCP manifest.
+func floatPtr[T int | float64](v *T) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+// toAny converts the values of an enum constraint for the MCP manifest.
+func toAny[T any](enum []T) []any {
+	if enum == nil {
+		return nil
+	}
+	rtn := make([]any, len(enum))
+	for i, v := range enum {
+		rtn[i] = v
+	}
+	return rtn
+}
+
 type ParamAuthService struct {
 	Name  string `yaml:"name"`
 	Field string `yaml:"field"`
@@ -517,6 +760,26 @@ var _ Parameter = &StringParameter{}
 // StringParameter is a parameter representing the "string" type.
 type StringParameter struct {
 	CommonParameter `yaml:",inline"`
+	Enum            []string `yaml:"enum"`
+	MinLength       *int     `yaml:"minLength"`
+	MaxLength       *int     `yaml:"maxLength"`
+	Pattern         string   `yaml:"pattern"`
+	Format          string   `yaml:"format"`
+}
+
+func (p *StringParameter) validateConstraints() error {
+	if err := checkRange("minLength", p.MinLength, "maxLength", p.MaxLength); err != nil {
+		return err
+	}
+	if p.Pattern != "" {
+		if _, err := compilePattern(p.Pattern); err != nil {
+			return err
+		}
+	}
+	if p.Format != "" && !slices.Contains(formats, p.Format) {
+		return fmt.Errorf("unknown format %q: must be one of %q", p.Format, formats)
+	}
+	return nil
 }
 
 // Parse casts the value "v" as a "string".
@@ -525,8 +788,43 @@ func (p *StringParameter) Parse(v any) (any, error) {
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, v}
 	}
+	if len(p.Enum) > 0 && !slices.Contains(p.Enum, newV) {
+		return nil, newConstraintError("enum", "%q is not one of %q", newV, p.Enum)
+	}
+	length := utf8.RuneCountInString(newV)
+	if p.MinLength != nil && length < *p.MinLength {
+		return nil, newConstraintError("minLength", "length %d is less than %d", length, *p.MinLength)
+	}
+	if p.MaxLength != nil && length > *p.MaxLength {
+		return nil, newConstraintError("maxLength", "length %d is greater than %d", length, *p.MaxLength)
+	}
+	if p.Pattern != "" {
+		re, err := compilePattern(p.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		if !re.MatchString(newV) {
+			return nil, newConstraintError("pattern", "%q doesn't match %q", newV, p.Pattern)
+		}
+	}
+	if p.Format != "" {
+		if err := checkFormat(p.Format, newV); err != nil {
+			return nil, err
+		}
+	}
 	return newV, nil
 }
+
+// McpManifest returns the MCP manifest for the StringParameter.
+func (p *StringParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Enum = toAny(p.Enum)
+	m.MinLength = p.MinLength
+	m.MaxLength = p.MaxLength
+	m.Pattern = p.Pattern
+	m.Format = p.Format
+	return m
+}
 func (p *StringParameter) GetAuthServices() []ParamAuthService {
 	return p.AuthServices
 }
@@ -573,6 +871,36 @@ var _ Parameter = &IntParameter{}
 // IntParameter is a parameter representing the "int" type.
 type IntParameter struct {
 	CommonParameter `yaml:",inline"`
+	Enum            []int `yaml:"enum"`
+	Minimum         *int  `yaml:"minimum"`
+	Maximum         *int  `yaml:"maximum"`
+}
+
+func (p *IntParameter) validateConstraints() error {
+	return checkRange("minimum", p.Minimum, "maximum", p.Maximum)
+}
+
+// checkConstraints returns an error if v violates a constraint.
+func (p *IntParameter) checkConstraints(v int) error {
+	if len(p.Enum) > 0 && !slices.Contains(p.Enum, v) {
+		return newConstraintError("enum", "%d is not one of %v", v, p.Enum)
+	}
+	if p.Minimum != nil && v < *p.Minimum {
+		return newConstraintError("minimum", "%d is less than %d", v, *p.Minimum)
+	}
+	if p.Maximum != nil && v > *p.Maximum {
+		return newConstraintError("maximum", "%d is greater than %d", v, *p.Maximum)
+	}
+	return nil
+}
+
+// McpManifest returns the MCP manifest for the IntParameter.
+func (p *IntParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Enum = toAny(p.Enum)
+	m.Minimum = floatPtr(p.Minimum)
+	m.Maximum = floatPtr(p.Maximum)
+	return m
 }
 
 func (p *IntParameter) Parse(v any) (any, error) {
@@ -593,6 +921,9 @@ func (p *IntParameter) Parse(v any) (any, error) {
 		}
 		out = int(newI)
 	}
+	if err := p.checkConstraints(out); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
@@ -642,6 +973,36 @@ var _ Parameter = &FloatParameter{}
 // FloatParameter is a parameter representing the "float" type.
 type FloatParameter struct {
 	CommonParameter `yaml:",inline"`
+	Enum            []float64 `yaml:"enum"`
+	Minimum         *float64  `yaml:"minimum"`
+	Maximum         *float64  `yaml:"maximum"`
+}
+
+func (p *FloatParameter) validateConstraints() error {
+	return checkRange("minimum", p.Minimum, "maximum", p.Maximum)
+}
+
+// checkConstraints returns an error if v violates a constraint.
+func (p *FloatParameter) checkConstraints(v float64) error {
+	if len(p.Enum) > 0 && !slices.Contains(p.Enum, v) {
+		return newConstraintError("enum", "%v is not one of %v", v, p.Enum)
+	}
+	if p.Minimum != nil && v < *p.Minimum {
+		return newConstraintError("minimum", "%v is less than %v", v, *p.Minimum)
+	}
+	if p.Maximum != nil && v > *p.Maximum {
+		return newConstraintError("maximum", "%v is greater than %v", v, *p.Maximum)
+	}
+	return nil
+}
+
+// McpManifest returns the MCP manifest for the FloatParameter.
+func (p *FloatParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Enum = toAny(p.Enum)
+	m.Minimum = p.Minimum
+	m.Maximum = p.Maximum
+	return m
 }
 
 func (p *FloatParameter) Parse(v any) (any, error) {
@@ -660,6 +1021,9 @@ func (p *FloatParameter) Parse(v any) (any, error) {
 		}
 		out = float64(newI)
 	}
+	if err := p.checkConstraints(out); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
@@ -723,6 +1087,225 @@ func (p *BooleanParameter) GetAuthServices() []ParamAuthService {
 	return p.AuthServices
 }
 
+// NewDateParameter is a convenience function for initializing a DateParameter.
+func NewDateParameter(name string, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDate,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateParameterWithDefault is a convenience function for initializing a DateParameter with default value.
+func NewDateParameterWithDefault(name string, defaultV string, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDate,
+			Default:      defaultV,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateParameterWithAuth is a convenience function for initializing a DateParameter with a list of ParamAuthService.
+func NewDateParameterWithAuth(name string, desc string, authServices []ParamAuthService) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDate,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &DateParameter{}
+
+// DateParameter is a parameter representing the "date" type. Values are
+// ISO-8601 dates, e.g. "2025-01-31", and are parsed as a time.Time at midnight
+// UTC.
+type DateParameter struct {
+	CommonParameter `yaml:",inline"`
+}
+
+func (p *DateParameter) Parse(v any) (any, error) {
+	switch v := v.(type) {
+	case time.Time:
+		// YAML defaults may already be decoded as a time
+		return time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC), nil
+	case string:
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an ISO-8601 date, e.g. \"2025-01-31\"", v)
+		}
+		return t, nil
+	}
+	return nil, &ParseTypeError{p.Name, p.Type, v}
+}
+
+func (p *DateParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// McpManifest returns the MCP manifest for the DateParameter.
+func (p *DateParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Format:      formatDate,
+	}
+}
+
+// NewTimestampParameter is a convenience function for initializing a TimestampParameter.
+func NewTimestampParameter(name string, desc string) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeTimestamp,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewTimestampParameterWithDefault is a convenience function for initializing a TimestampParameter with default value.
+func NewTimestampParameterWithDefault(name string, defaultV string, desc string) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeTimestamp,
+			Default:      defaultV,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewTimestampParameterWithAuth is a convenience function for initializing a TimestampParameter with a list of ParamAuthService.
+func NewTimestampParameterWithAuth(name string, desc string, authServices []ParamAuthService) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeTimestamp,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &TimestampParameter{}
+
+// TimestampParameter is a parameter representing the "timestamp" type. Values
+// are ISO-8601 timestamps with a time zone offset, e.g.
+// "2025-01-31T09:30:00Z", and are parsed as a time.Time.
+type TimestampParameter struct {
+	CommonParameter `yaml:",inline"`
+}
+
+func (p *TimestampParameter) Parse(v any) (any, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an ISO-8601 timestamp, e.g. \"2025-01-31T09:30:00Z\"", v)
+		}
+		return t, nil
+	}
+	return nil, &ParseTypeError{p.Name, p.Type, v}
+}
+
+func (p *TimestampParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// McpManifest returns the MCP manifest for the TimestampParameter.
+func (p *TimestampParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        "string",
+		Description: p.Desc,
+		Format:      formatDateTime,
+	}
+}
+
+// NewBytesParameter is a convenience function for initializing a BytesParameter.
+func NewBytesParameter(name string, desc string) *BytesParameter {
+	return &BytesParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBytes,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewBytesParameterWithDefault is a convenience function for initializing a BytesParameter with default value.
+func NewBytesParameterWithDefault(name string, defaultV string, desc string) *BytesParameter {
+	return &BytesParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBytes,
+			Default:      defaultV,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewBytesParameterWithAuth is a convenience function for initializing a BytesParameter with a list of ParamAuthService.
+func NewBytesParameterWithAuth(name string, desc string, authServices []ParamAuthService) *BytesParameter {
+	return &BytesParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeBytes,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+	}
+}
+
+var _ Parameter = &BytesParameter{}
+
+// BytesParameter is a parameter representing the "bytes" type. Values are
+// base64 encoded strings, and are parsed as a []byte.
+type BytesParameter struct {
+	CommonParameter `yaml:",inline"`
+}
+
+func (p *BytesParameter) Parse(v any) (any, error) {
+	switch v := v.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a base64 encoded string", v)
+		}
+		return b, nil
+	}
+	return nil, &ParseTypeError{p.Name, p.Type, v}
+}
+
+func (p *BytesParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// McpManifest returns the MCP manifest for the BytesParameter.
+func (p *BytesParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:            "string",
+		Description:     p.Desc,
+		ContentEncoding: "base64",
+	}
+}
+
 // NewArrayParameter is a convenience function for initializing a ArrayParameter.
 func NewArrayParameter(name string, desc string, items Parameter) *ArrayParameter {
 	return &ArrayParameter{
@@ -769,17 +1352,23 @@ var _ Parameter = &ArrayParameter{}
 type ArrayParameter struct {
 	CommonParameter `yaml:",inline"`
 	Items           Parameter `yaml:"items"`
+	MinItems        *int      `yaml:"minItems"`
+	MaxItems        *int      `yaml:"maxItems"`
 }
 
 func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
 	var rawItem struct {
 		CommonParameter `yaml:",inline"`
 		Items           util.DelayedUnmarshaler `yaml:"items"`
+		MinItems        *int                    `yaml:"minItems"`
+		MaxItems        *int                    `yaml:"maxItems"`
 	}
 	if err := unmarshal(&rawItem); err != nil {
 		return err
 	}
 	p.CommonParameter = rawItem.CommonParameter
+	p.MinItems = rawItem.MinItems
+	p.MaxItems = rawItem.MaxItems
 	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
 	if err != nil {
 		return fmt.Errorf("unable to parse 'items' field: %w", err)
@@ -792,11 +1381,21 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 	return nil
 }
 
+func (p *ArrayParameter) validateConstraints() error {
+	return checkRange("minItems", p.MinItems, "maxItems", p.MaxItems)
+}
+
 func (p *ArrayParameter) Parse(v any) (any, error) {
 	arrVal, ok := v.([]any)
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, arrVal}
 	}
+	if p.MinItems != nil && len(arrVal) < *p.MinItems {
+		return nil, newConstraintError("minItems", "%d items are less than %d", len(arrVal), *p.MinItems)
+	}
+	if p.MaxItems != nil && len(arrVal) > *p.MaxItems {
+		return nil, newConstraintError("maxItems", "%d items are more than %d", len(arrVal), *p.MaxItems)
+	}
 	rtn := make([]any, 0, len(arrVal))
 	for idx, val := range arrVal {
 		val, err := p.Items.Parse(val)
@@ -820,9 +1419,8 @@ func (p *ArrayParameter) Manifest() ParameterManifest {
 		authNames[i] = a.Name
 	}
 	items := p.Items.Manifest()
-	var required bool
-	if p.Default == nil {
-		required = true
+	required := p.GetRequired()
+	if required {
 		items.Required = true
 	}
 	return ParameterManifest{
@@ -847,5 +1445,241 @@ func (p *ArrayParameter) McpManifest() ParameterMcpManifest {
 		Type:        p.Type,
 		Description: p.Desc,
 		Items:       &items,
+		MinItems:    p.MinItems,
+		MaxItems:    p.MaxItems,
+	}
+}
+
+// NewObjectParameter is a convenience function for initializing a ObjectParameter.
+func NewObjectParameter(name string, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithDefault is a convenience function for initializing a ObjectParameter with default value.
+func NewObjectParameterWithDefault(name string, defaultV any, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeObject,
+			Default:      defaultV,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithAuth is a convenience function for initializing a ObjectParameter with a list of ParamAuthService.
+func NewObjectParameterWithAuth(name string, desc string, properties Parameters, authServices []ParamAuthService) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeObject,
+			Desc:         desc,
+			AuthServices: authServices,
+		},
+		Properties: properties,
+	}
+}
+
+var _ Parameter = &ObjectParameter{}
+
+// ObjectParameter is a parameter representing the "object" type.
+type ObjectParameter struct {
+	CommonParameter `yaml:",inline"`
+	Properties      Parameters `yaml:"properties"`
+	// Required lists the properties that must be set. If it is nil, the
+	// required properties are determined by the properties themselves. It
+	// shadows CommonParameter.Required, which is set if `required` is a bool.
+	Required []string `yaml:"required"`
+	// AdditionalProperties allows properties that are not listed in
+	// Properties. If AdditionalPropertiesSchema is set, their values are
+	// parsed with it.
+	AdditionalProperties       bool      `yaml:"additionalProperties"`
+	AdditionalPropertiesSchema Parameter `yaml:"-"`
+}
+
+func (p *ObjectParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	// `required` is either the list of required properties, or a bool like
+	// for the other parameters
+	var fields map[string]any
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	if required, ok := fields["required"].([]any); ok {
+		p.Required = make([]string, 0, len(required))
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				return fmt.Errorf("'required' must be a bool or a list of property names")
+			}
+			p.Required = append(p.Required, name)
+		}
+		delete(fields, "required")
+	}
+	dec, err := util.NewStrictDecoder(fields)
+	if err != nil {
+		return fmt.Errorf("error creating decoder: %w", err)
+	}
+	var rawObject struct {
+		CommonParameter      `yaml:",inline"`
+		Properties           Parameters               `yaml:"properties"`
+		AdditionalProperties *util.DelayedUnmarshaler `yaml:"additionalProperties"`
+	}
+	if err := dec.DecodeContext(ctx, &rawObject); err != nil {
+		return err
+	}
+	p.CommonParameter = rawObject.CommonParameter
+	p.Properties = rawObject.Properties
+
+	seen := make(map[string]bool)
+	for _, prop := range p.Properties {
+		if len(prop.GetAuthServices()) != 0 {
+			return fmt.Errorf("nested properties should not have auth services")
+		}
+		if seen[prop.GetName()] {
+			return fmt.Errorf("duplicate property %q", prop.GetName())
+		}
+		seen[prop.GetName()] = true
+	}
+	for _, name := range p.Required {
+		if !seen[name] {
+			return fmt.Errorf("required property %q is not listed in 'properties'", name)
+		}
+	}
+
+	if rawObject.AdditionalProperties == nil {
+		return nil
+	}
+	// additionalProperties is either a bool, or the schema of the values
+	var additional any
+	if err := rawObject.AdditionalProperties.Unmarshal(&additional); err != nil {
+		return fmt.Errorf("unable to parse 'additionalProperties' field: %w", err)
+	}
+	switch a := additional.(type) {
+	case bool:
+		p.AdditionalProperties = a
+	default:
+		schema, err := parseParamFromDelayedUnmarshaler(ctx, rawObject.AdditionalProperties)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'additionalProperties' field: %w", err)
+		}
+		if len(schema.GetAuthServices()) != 0 {
+			return fmt.Errorf("additional properties should not have auth services")
+		}
+		p.AdditionalProperties = true
+		p.AdditionalPropertiesSchema = schema
+	}
+	return nil
+}
+
+// isRequired returns true if the property must be set.
+func (p *ObjectParameter) isRequired(prop Parameter) bool {
+	if p.Required != nil {
+		return slices.Contains(p.Required, prop.GetName())
+	}
+	return prop.GetRequired()
+}
+
+func (p *ObjectParameter) Parse(v any) (any, error) {
+	objVal, ok := v.(map[string]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	rtn := make(map[string]any, len(objVal))
+	declared := make(map[string]bool, len(p.Properties))
+	for _, prop := range p.Properties {
+		name := prop.GetName()
+		declared[name] = true
+		// like for parameters, an explicit null is handled like a missing
+		// value
+		val, ok := objVal[name]
+		if val == nil {
+			if d := prop.GetDefault(); d != nil {
+				newD, err := prop.Parse(d)
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse default of property %q: %w", name, err)
+				}
+				rtn[name] = newD
+				continue
+			}
+			if p.isRequired(prop) {
+				return nil, fmt.Errorf("missing required property %q", name)
+			}
+			if ok {
+				rtn[name] = nil
+			}
+			continue
+		}
+		newV, err := prop.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+		}
+		rtn[name] = newV
+	}
+	for name, val := range objVal {
+		if declared[name] {
+			continue
+		}
+		if !p.AdditionalProperties {
+			return nil, fmt.Errorf("unknown property %q", name)
+		}
+		if p.AdditionalPropertiesSchema != nil {
+			newV, err := p.AdditionalPropertiesSchema.Parse(val)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+			}
+			val = newV
+		}
+		rtn[name] = val
+	}
+	return rtn, nil
+}
+
+func (p *ObjectParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// Manifest returns the manifest for the ObjectParameter.
+func (p *ObjectParameter) Manifest() ParameterManifest {
+	m := p.CommonParameter.Manifest()
+	m.Properties = make([]ParameterManifest, 0, len(p.Properties))
+	for _, prop := range p.Properties {
+		propManifest := prop.Manifest()
+		propManifest.Required = p.isRequired(prop)
+		m.Properties = append(m.Properties, propManifest)
+	}
+	m.AdditionalProperties = p.AdditionalProperties
+	if p.AdditionalPropertiesSchema != nil {
+		schema := p.AdditionalPropertiesSchema.Manifest()
+		m.AdditionalProperties = &schema
+	}
+	return m
+}
+
+// McpManifest returns the MCP manifest for the ObjectParameter.
+func (p *ObjectParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Properties = make(map[string]ParameterMcpManifest, len(p.Properties))
+	m.Required = make([]string, 0)
+	for _, prop := range p.Properties {
+		m.Properties[prop.GetName()] = prop.McpManifest()
+		if p.isRequired(prop) {
+			m.Required = append(m.Required, prop.GetName())
+		}
+	}
+	m.AdditionalProperties = p.AdditionalProperties
+	if p.AdditionalPropertiesSchema != nil {
+		schema := p.AdditionalPropertiesSchema.McpManifest()
+		m.AdditionalProperties = &schema
 	}
+	return m
 }
@@ -20,9 +20,11 @@ import (
 	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
@@ -32,6 +34,8 @@ func TestParametersMarshal(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
+	one, three, ten := 1, 3, 10
+	optional := false
 	tcs := []struct {
 		name string
 		in   []map[string]any
@@ -50,6 +54,121 @@ func TestParametersMarshal(t *testing.T) {
 				tools.NewStringParameter("my_string", "this param is a string"),
 			},
 		},
+		{
+			name: "date, timestamp and bytes",
+			in: []map[string]any{
+				{
+					"name":        "my_date",
+					"type":        "date",
+					"description": "this param is a date",
+					"default":     "2025-01-31",
+				},
+				{
+					"name":        "my_timestamp",
+					"type":        "timestamp",
+					"description": "this param is a timestamp",
+				},
+				{
+					"name":        "my_bytes",
+					"type":        "bytes",
+					"description": "this param is bytes",
+				},
+			},
+			want: tools.Parameters{
+				tools.NewDateParameterWithDefault("my_date", "2025-01-31", "this param is a date"),
+				tools.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+				tools.NewBytesParameter("my_bytes", "this param is bytes"),
+			},
+		},
+		{
+			name: "optional",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"required":    false,
+				},
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"required":    false,
+					"properties": []map[string]any{
+						{
+							"name":        "my_integer",
+							"type":        "integer",
+							"description": "int property",
+							"required":    false,
+						},
+					},
+				},
+			},
+			want: tools.Parameters{
+				&tools.StringParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string", Required: &optional},
+				},
+				&tools.ObjectParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_object", Type: "object", Desc: "this param is an object", Required: &optional},
+					Properties: tools.Parameters{
+						&tools.IntParameter{
+							CommonParameter: tools.CommonParameter{Name: "my_integer", Type: "integer", Desc: "int property", Required: &optional},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "constraints",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"enum":        []string{"a", "b"},
+					"minLength":   1,
+					"pattern":     "^[a-z]+$",
+					"format":      "email",
+				},
+				{
+					"name":        "my_integer",
+					"type":        "integer",
+					"description": "this param is an int",
+					"minimum":     1,
+					"maximum":     10,
+				},
+				{
+					"name":        "my_array",
+					"type":        "array",
+					"description": "this param is an array of strings",
+					"maxItems":    3,
+					"items": map[string]string{
+						"name":        "my_string",
+						"type":        "string",
+						"description": "string item",
+					},
+				},
+			},
+			want: tools.Parameters{
+				&tools.StringParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					Enum:            []string{"a", "b"},
+					MinLength:       &one,
+					Pattern:         "^[a-z]+$",
+					Format:          "email",
+				},
+				&tools.IntParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_integer", Type: "integer", Desc: "this param is an int"},
+					Minimum:         &one,
+					Maximum:         &ten,
+				},
+				&tools.ArrayParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array of strings"},
+					Items:           tools.NewStringParameter("my_string", "string item"),
+					MaxItems:        &three,
+				},
+			},
+		},
 		{
 			name: "int",
 			in: []map[string]any{
@@ -219,6 +338,63 @@ func TestParametersMarshal(t *testing.T) {
 				tools.NewArrayParameterWithDefault("my_array", "[1.0, 1.1]", "this param is an array of floats", tools.NewFloatParameter("my_float", "float item")),
 			},
 		},
+		{
+			name: "object",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "my_string",
+							"type":        "string",
+							"description": "string property",
+						},
+						{
+							"name":        "my_int",
+							"type":        "integer",
+							"description": "int property",
+						},
+					},
+					"required": []string{"my_string"},
+					"additionalProperties": map[string]any{
+						"name":        "my_tag",
+						"type":        "string",
+						"description": "tag value",
+					},
+				},
+			},
+			want: tools.Parameters{
+				&tools.ObjectParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_object", Type: "object", Desc: "this param is an object"},
+					Properties: tools.Parameters{
+						tools.NewStringParameter("my_string", "string property"),
+						tools.NewIntParameter("my_int", "int property"),
+					},
+					Required:                   []string{"my_string"},
+					AdditionalProperties:       true,
+					AdditionalPropertiesSchema: tools.NewStringParameter("my_tag", "tag value"),
+				},
+			},
+		},
+		{
+			name: "object with additional properties",
+			in: []map[string]any{
+				{
+					"name":                 "my_object",
+					"type":                 "object",
+					"description":          "this param is a map",
+					"additionalProperties": true,
+				},
+			},
+			want: tools.Parameters{
+				&tools.ObjectParameter{
+					CommonParameter:      tools.CommonParameter{Name: "my_object", Type: "object", Desc: "this param is a map"},
+					AdditionalProperties: true,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -541,6 +717,7 @@ func TestAuthParametersMarshal(t *testing.T) {
 }
 
 func TestParametersParse(t *testing.T) {
+	optional := false
 	tcs := []struct {
 		name   string
 		params tools.Parameters
@@ -566,6 +743,58 @@ func TestParametersParse(t *testing.T) {
 				"my_string": 4,
 			},
 		},
+		{
+			name: "missing required string",
+			params: tools.Parameters{
+				tools.NewStringParameter("my_string", "this param is a string"),
+			},
+			in: map[string]any{},
+		},
+		{
+			name: "null required string",
+			params: tools.Parameters{
+				tools.NewStringParameter("my_string", "this param is a string"),
+			},
+			in: map[string]any{
+				"my_string": nil,
+			},
+		},
+		{
+			name: "missing optional string",
+			params: tools.Parameters{
+				&tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Required: &optional}},
+			},
+			in:   map[string]any{},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_string", Value: nil}},
+		},
+		{
+			name: "null optional int",
+			params: tools.Parameters{
+				&tools.IntParameter{CommonParameter: tools.CommonParameter{Name: "my_int", Type: "integer", Required: &optional}},
+			},
+			in: map[string]any{
+				"my_int": nil,
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_int", Value: nil}},
+		},
+		{
+			name: "missing with default",
+			params: tools.Parameters{
+				tools.NewStringParameterWithDefault("my_string", "foo", "this param is a string"),
+			},
+			in:   map[string]any{},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_string", Value: "foo"}},
+		},
+		{
+			name: "null with default",
+			params: tools.Parameters{
+				tools.NewStringParameterWithDefault("my_string", "foo", "this param is a string"),
+			},
+			in: map[string]any{
+				"my_string": nil,
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_string", Value: nil}},
+		},
 		{
 			name: "int",
 			params: tools.Parameters{
@@ -877,133 +1106,603 @@ func TestAuthParametersParse(t *testing.T) {
 	}
 }
 
-func TestParamValues(t *testing.T) {
+func TestObjectParameterParse(t *testing.T) {
+	address := &tools.ObjectParameter{
+		CommonParameter: tools.CommonParameter{Name: "address", Type: "object", Desc: "an address"},
+		Properties: tools.Parameters{
+			tools.NewStringParameter("street", "the street"),
+			tools.NewStringParameterWithDefault("country", "US", "the country"),
+		},
+	}
 	tcs := []struct {
-		name              string
-		in                tools.ParamValues
-		wantSlice         []any
-		wantMap           map[string]interface{}
-		wantMapOrdered    map[string]interface{}
-		wantMapWithDollar map[string]interface{}
+		name  string
+		param tools.Parameter
+		in    any
+		want  any
+		err   string
 	}{
 		{
-			name:           "string",
-			in:             tools.ParamValues{tools.ParamValue{Name: "my_bool", Value: true}, tools.ParamValue{Name: "my_string", Value: "hello world"}},
-			wantSlice:      []any{true, "hello world"},
-			wantMap:        map[string]interface{}{"my_bool": true, "my_string": "hello world"},
-			wantMapOrdered: map[string]interface{}{"p1": true, "p2": "hello world"},
-			wantMapWithDollar: map[string]interface{}{
-				"$my_bool":   true,
-				"$my_string": "hello world",
+			name:  "object",
+			param: address,
+			in:    map[string]any{"street": "1600 Amphitheatre Pkwy", "country": "CH"},
+			want:  map[string]any{"street": "1600 Amphitheatre Pkwy", "country": "CH"},
+		},
+		{
+			name:  "default property",
+			param: address,
+			in:    map[string]any{"street": "1600 Amphitheatre Pkwy"},
+			want:  map[string]any{"street": "1600 Amphitheatre Pkwy", "country": "US"},
+		},
+		{
+			name: "date default property",
+			param: &tools.ObjectParameter{
+				CommonParameter: tools.CommonParameter{Name: "range", Type: "object", Desc: "a date range"},
+				Properties:      tools.Parameters{tools.NewDateParameterWithDefault("since", "2025-01-31", "the start")},
+			},
+			in:   map[string]any{},
+			want: map[string]any{"since": time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "invalid default property",
+			param: &tools.ObjectParameter{
+				CommonParameter: tools.CommonParameter{Name: "address", Type: "object", Desc: "an address"},
+				Properties: tools.Parameters{&tools.StringParameter{
+					CommonParameter: tools.CommonParameter{Name: "country", Type: "string", Desc: "the country", Default: "FR"},
+					Enum:            []string{"US", "CH"},
+				}},
+			},
+			in:  map[string]any{},
+			err: `unable to parse default of property "country": violates "enum" constraint: "FR" is not one of ["US" "CH"]`,
+		},
+		{
+			name:  "missing property",
+			param: address,
+			in:    map[string]any{"country": "CH"},
+			err:   `missing required property "street"`,
+		},
+		{
+			name:  "wrong property type",
+			param: address,
+			in:    map[string]any{"street": false},
+			err:   `unable to parse property "street": %!q(bool=false) not type "string"`,
+		},
+		{
+			name:  "null property",
+			param: address,
+			in:    map[string]any{"street": "1600 Amphitheatre Pkwy", "country": nil},
+			want:  map[string]any{"street": "1600 Amphitheatre Pkwy", "country": "US"},
+		},
+		{
+			name:  "null required property",
+			param: address,
+			in:    map[string]any{"street": nil},
+			err:   `missing required property "street"`,
+		},
+		{
+			name:  "unknown property",
+			param: address,
+			in:    map[string]any{"street": "1600 Amphitheatre Pkwy", "city": "Mountain View"},
+			err:   `unknown property "city"`,
+		},
+		{
+			name:  "not object",
+			param: address,
+			in:    "1600 Amphitheatre Pkwy",
+			err:   `"1600 Amphitheatre Pkwy" not type "object"`,
+		},
+		{
+			name: "nested object",
+			param: &tools.ObjectParameter{
+				CommonParameter: tools.CommonParameter{Name: "user", Type: "object", Desc: "a user"},
+				Properties: tools.Parameters{
+					tools.NewStringParameter("name", "the name"),
+					address,
+					tools.NewArrayParameter("tags", "the tags", tools.NewStringParameter("tag", "a tag")),
+				},
+				Required: []string{"name"},
+			},
+			in: map[string]any{
+				"name":    "alice",
+				"address": map[string]any{"street": "1600 Amphitheatre Pkwy"},
+				"tags":    []any{"admin"},
+			},
+			want: map[string]any{
+				"name":    "alice",
+				"address": map[string]any{"street": "1600 Amphitheatre Pkwy", "country": "US"},
+				"tags":    []any{"admin"},
+			},
+		},
+		{
+			name: "nested error",
+			param: &tools.ObjectParameter{
+				CommonParameter: tools.CommonParameter{Name: "user", Type: "object", Desc: "a user"},
+				Properties:      tools.Parameters{address},
+			},
+			in:  map[string]any{"address": map[string]any{"street": true}},
+			err: `unable to parse property "address": unable to parse property "street": %!q(bool=true) not type "string"`,
+		},
+		{
+			name: "additional properties",
+			param: &tools.ObjectParameter{
+				CommonParameter:            tools.CommonParameter{Name: "labels", Type: "object", Desc: "labels"},
+				AdditionalProperties:       true,
+				AdditionalPropertiesSchema: tools.NewIntParameter("label", "a label"),
+			},
+			in:   map[string]any{"a": json.Number("1"), "b": 2},
+			want: map[string]any{"a": 1, "b": 2},
+		},
+		{
+			name: "wrong additional property type",
+			param: &tools.ObjectParameter{
+				CommonParameter:            tools.CommonParameter{Name: "labels", Type: "object", Desc: "labels"},
+				AdditionalProperties:       true,
+				AdditionalPropertiesSchema: tools.NewIntParameter("label", "a label"),
+			},
+			in:  map[string]any{"a": "one"},
+			err: `unable to parse property "a": "one" not type "integer"`,
+		},
+		{
+			name: "free-form object",
+			param: &tools.ObjectParameter{
+				CommonParameter:      tools.CommonParameter{Name: "doc", Type: "object", Desc: "a document"},
+				AdditionalProperties: true,
 			},
+			in:   map[string]any{"a": []any{1, "b"}},
+			want: map[string]any{"a": []any{1, "b"}},
 		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			gotSlice := tc.in.AsSlice()
-			gotMap := tc.in.AsMap()
-			gotMapOrdered := tc.in.AsMapByOrderedKeys()
-			gotMapWithDollar := tc.in.AsMapWithDollarPrefix()
-
-			for i, got := range gotSlice {
-				want := tc.wantSlice[i]
-				if got != want {
-					t.Fatalf("unexpected value: got %q, want %q", got, want)
+			got, err := tc.param.Parse(tc.in)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("expected error but Param parsed successfully: %v", got)
 				}
-			}
-			for i, got := range gotMap {
-				want := tc.wantMap[i]
-				if got != want {
-					t.Fatalf("unexpected value: got %q, want %q", got, want)
+				if err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
 				}
+				return
 			}
-			for i, got := range gotMapOrdered {
-				want := tc.wantMapOrdered[i]
-				if got != want {
-					t.Fatalf("unexpected value: got %q, want %q", got, want)
-				}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
 			}
-			for key, got := range gotMapWithDollar {
-				want := tc.wantMapWithDollar[key]
-				if got != want {
-					t.Fatalf("unexpected value in AsMapWithDollarPrefix: got %q, want %q", got, want)
-				}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
 			}
 		})
 	}
 }
 
-func TestParamManifest(t *testing.T) {
+func TestParameterConstraints(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	floatPtr := func(f float64) *float64 { return &f }
 	tcs := []struct {
-		name string
-		in   tools.Parameter
-		want tools.ParameterManifest
+		name  string
+		param tools.Parameter
+		in    any
+		want  any
+		err   string
 	}{
 		{
-			name: "string",
-			in:   tools.NewStringParameter("foo-string", "bar"),
-			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+			name:  "string enum",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "order", Type: "string"}, Enum: []string{"asc", "desc"}},
+			in:    "asc",
+			want:  "asc",
 		},
 		{
-			name: "int",
-			in:   tools.NewIntParameter("foo-int", "bar"),
-			want: tools.ParameterManifest{Name: "foo-int", Type: "integer", Required: true, Description: "bar", AuthServices: []string{}},
+			name:  "string not in enum",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "order", Type: "string"}, Enum: []string{"asc", "desc"}},
+			in:    "up",
+			err:   `violates "enum" constraint: "up" is not one of ["asc" "desc"]`,
 		},
 		{
-			name: "float",
-			in:   tools.NewFloatParameter("foo-float", "bar"),
-			want: tools.ParameterManifest{Name: "foo-float", Type: "float", Required: true, Description: "bar", AuthServices: []string{}},
+			name:  "string too short",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "code", Type: "string"}, MinLength: intPtr(2), MaxLength: intPtr(3)},
+			in:    "a",
+			err:   `violates "minLength" constraint: length 1 is less than 2`,
 		},
 		{
-			name: "boolean",
-			in:   tools.NewBooleanParameter("foo-bool", "bar"),
-			want: tools.ParameterManifest{Name: "foo-bool", Type: "boolean", Required: true, Description: "bar", AuthServices: []string{}},
+			name:  "string too long",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "code", Type: "string"}, MinLength: intPtr(2), MaxLength: intPtr(3)},
+			in:    "abcd",
+			err:   `violates "maxLength" constraint: length 4 is greater than 3`,
 		},
 		{
-			name: "array",
-			in:   tools.NewArrayParameter("foo-array", "bar", tools.NewStringParameter("foo-string", "bar")),
-			want: tools.ParameterManifest{
-				Name:         "foo-array",
-				Type:         "array",
-				Required:     true,
-				Description:  "bar",
-				AuthServices: []string{},
-				Items:        &tools.ParameterManifest{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
-			},
+			name:  "length counts characters",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "code", Type: "string"}, MaxLength: intPtr(3)},
+			in:    "äöü",
+			want:  "äöü",
 		},
 		{
-			name: "string default",
-			in:   tools.NewStringParameterWithDefault("foo-string", "foo", "bar"),
-			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Required: false, Description: "bar", AuthServices: []string{}},
+			name:  "pattern",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "sku", Type: "string"}, Pattern: "^[A-Z]{3}-[0-9]+$"},
+			in:    "ABC-123",
+			want:  "ABC-123",
 		},
 		{
-			name: "int default",
-			in:   tools.NewIntParameterWithDefault("foo-int", 1, "bar"),
-			want: tools.ParameterManifest{Name: "foo-int", Type: "integer", Required: false, Description: "bar", AuthServices: []string{}},
+			name:  "pattern mismatch",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "sku", Type: "string"}, Pattern: "^[A-Z]{3}-[0-9]+$"},
+			in:    "abc-123",
+			err:   `violates "pattern" constraint: "abc-123" doesn't match "^[A-Z]{3}-[0-9]+$"`,
 		},
 		{
-			name: "float default",
-			in:   tools.NewFloatParameterWithDefault("foo-float", 1.1, "bar"),
-			want: tools.ParameterManifest{Name: "foo-float", Type: "float", Required: false, Description: "bar", AuthServices: []string{}},
+			name:  "date",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "day", Type: "string"}, Format: "date"},
+			in:    "2025-02-28",
+			want:  "2025-02-28",
 		},
 		{
-			name: "boolean default",
-			in:   tools.NewBooleanParameterWithDefault("foo-bool", true, "bar"),
-			want: tools.ParameterManifest{Name: "foo-bool", Type: "boolean", Required: false, Description: "bar", AuthServices: []string{}},
+			name:  "invalid date",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "day", Type: "string"}, Format: "date"},
+			in:    "2025-02-30",
+			err:   `violates "format" constraint: "2025-02-30" is not a valid date`,
 		},
 		{
-			name: "array default",
-			in:   tools.NewArrayParameterWithDefault("foo-array", `["foo", "bar"]`, "bar", tools.NewStringParameter("foo-string", "bar")),
-			want: tools.ParameterManifest{
-				Name:         "foo-array",
-				Type:         "array",
-				Required:     false,
-				Description:  "bar",
-				AuthServices: []string{},
-				Items:        &tools.ParameterManifest{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
-			},
+			name:  "date-time",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "at", Type: "string"}, Format: "date-time"},
+			in:    "2025-02-28T10:00:00+01:00",
+			want:  "2025-02-28T10:00:00+01:00",
 		},
-	}
+		{
+			name:  "invalid date-time",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "at", Type: "string"}, Format: "date-time"},
+			in:    "2025-02-28 10:00",
+			err:   `violates "format" constraint: "2025-02-28 10:00" is not a valid date-time`,
+		},
+		{
+			name:  "uuid",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "id", Type: "string"}, Format: "uuid"},
+			in:    "123e4567-e89b-12d3-a456-426614174000",
+			want:  "123e4567-e89b-12d3-a456-426614174000",
+		},
+		{
+			name:  "invalid uuid",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "id", Type: "string"}, Format: "uuid"},
+			in:    "{123e4567-e89b-12d3-a456-426614174000}",
+			err:   `violates "format" constraint: "{123e4567-e89b-12d3-a456-426614174000}" is not a valid uuid`,
+		},
+		{
+			name:  "email",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "email", Type: "string"}, Format: "email"},
+			in:    "jane@example.com",
+			want:  "jane@example.com",
+		},
+		{
+			name:  "invalid email",
+			param: &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "email", Type: "string"}, Format: "email"},
+			in:    "Jane <jane@example.com>",
+			err:   `violates "format" constraint: "Jane <jane@example.com>" is not a valid email`,
+		},
+		{
+			name:  "int range",
+			param: &tools.IntParameter{CommonParameter: tools.CommonParameter{Name: "limit", Type: "integer"}, Minimum: intPtr(1), Maximum: intPtr(100)},
+			in:    100,
+			want:  100,
+		},
+		{
+			name:  "int below minimum",
+			param: &tools.IntParameter{CommonParameter: tools.CommonParameter{Name: "limit", Type: "integer"}, Minimum: intPtr(1), Maximum: intPtr(100)},
+			in:    0,
+			err:   `violates "minimum" constraint: 0 is less than 1`,
+		},
+		{
+			name:  "int above maximum",
+			param: &tools.IntParameter{CommonParameter: tools.CommonParameter{Name: "limit", Type: "integer"}, Minimum: intPtr(1), Maximum: intPtr(100)},
+			in:    101,
+			err:   `violates "maximum" constraint: 101 is greater than 100`,
+		},
+		{
+			name:  "int not in enum",
+			param: &tools.IntParameter{CommonParameter: tools.CommonParameter{Name: "size", Type: "integer"}, Enum: []int{10, 20}},
+			in:    15,
+			err:   `violates "enum" constraint: 15 is not one of [10 20]`,
+		},
+		{
+			name:  "float in range",
+			param: &tools.FloatParameter{CommonParameter: tools.CommonParameter{Name: "ratio", Type: "float"}, Minimum: floatPtr(0), Maximum: floatPtr(1)},
+			in:    0.5,
+			want:  0.5,
+		},
+		{
+			name:  "float above maximum",
+			param: &tools.FloatParameter{CommonParameter: tools.CommonParameter{Name: "ratio", Type: "float"}, Minimum: floatPtr(0), Maximum: floatPtr(1)},
+			in:    1.5,
+			err:   `violates "maximum" constraint: 1.5 is greater than 1`,
+		},
+		{
+			name: "array too short",
+			param: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "ids", Type: "array"},
+				Items:           tools.NewIntParameter("id", "an id"),
+				MinItems:        intPtr(1),
+				MaxItems:        intPtr(2),
+			},
+			in:  []any{},
+			err: `violates "minItems" constraint: 0 items are less than 1`,
+		},
+		{
+			name: "array too long",
+			param: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "ids", Type: "array"},
+				Items:           tools.NewIntParameter("id", "an id"),
+				MinItems:        intPtr(1),
+				MaxItems:        intPtr(2),
+			},
+			in:  []any{1, 2, 3},
+			err: `violates "maxItems" constraint: 3 items are more than 2`,
+		},
+		{
+			name: "array item constraint",
+			param: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "codes", Type: "array"},
+				Items:           &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "code", Type: "string"}, MaxLength: intPtr(2)},
+			},
+			in:  []any{"ab", "abc"},
+			err: `unable to parse element #1: violates "maxLength" constraint: length 3 is greater than 2`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.param.Parse(tc.in)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("expected error but Param parsed successfully: %v", got)
+				}
+				if err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestTemporalAndBytesParameterParse(t *testing.T) {
+	tcs := []struct {
+		name  string
+		param tools.Parameter
+		in    any
+		want  any
+		err   string
+	}{
+		{
+			name:  "date",
+			param: tools.NewDateParameter("day", "a day"),
+			in:    "2025-01-31",
+			want:  time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "date from yaml",
+			param: tools.NewDateParameter("day", "a day"),
+			in:    time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC),
+			want:  time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "invalid date",
+			param: tools.NewDateParameter("day", "a day"),
+			in:    "31/01/2025",
+			err:   `"31/01/2025" is not an ISO-8601 date, e.g. "2025-01-31"`,
+		},
+		{
+			name:  "not date",
+			param: tools.NewDateParameter("day", "a day"),
+			in:    true,
+			err:   `%!q(bool=true) not type "date"`,
+		},
+		{
+			name:  "timestamp",
+			param: tools.NewTimestampParameter("at", "a time"),
+			in:    "2025-01-31T09:30:00.5+01:00",
+			want:  time.Date(2025, 1, 31, 9, 30, 0, 500000000, time.FixedZone("", 3600)),
+		},
+		{
+			name:  "timestamp without offset",
+			param: tools.NewTimestampParameter("at", "a time"),
+			in:    "2025-01-31T09:30:00",
+			err:   `"2025-01-31T09:30:00" is not an ISO-8601 timestamp, e.g. "2025-01-31T09:30:00Z"`,
+		},
+		{
+			name:  "bytes",
+			param: tools.NewBytesParameter("data", "some data"),
+			in:    "aGVsbG8=",
+			want:  []byte("hello"),
+		},
+		{
+			name:  "invalid bytes",
+			param: tools.NewBytesParameter("data", "some data"),
+			in:    "hello!",
+			err:   `"hello!" is not a base64 encoded string`,
+		},
+		{
+			name:  "array of dates",
+			param: tools.NewArrayParameter("days", "some days", tools.NewDateParameter("day", "a day")),
+			in:    []any{"2025-01-31", "2025-02-01"},
+			want:  []any{time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.param.Parse(tc.in)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("expected error but Param parsed successfully: %v", got)
+				}
+				if err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParamValues(t *testing.T) {
+	tcs := []struct {
+		name              string
+		in                tools.ParamValues
+		wantSlice         []any
+		wantMap           map[string]interface{}
+		wantMapOrdered    map[string]interface{}
+		wantMapWithDollar map[string]interface{}
+	}{
+		{
+			name:           "string",
+			in:             tools.ParamValues{tools.ParamValue{Name: "my_bool", Value: true}, tools.ParamValue{Name: "my_string", Value: "hello world"}},
+			wantSlice:      []any{true, "hello world"},
+			wantMap:        map[string]interface{}{"my_bool": true, "my_string": "hello world"},
+			wantMapOrdered: map[string]interface{}{"p1": true, "p2": "hello world"},
+			wantMapWithDollar: map[string]interface{}{
+				"$my_bool":   true,
+				"$my_string": "hello world",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSlice := tc.in.AsSlice()
+			gotMap := tc.in.AsMap()
+			gotMapOrdered := tc.in.AsMapByOrderedKeys()
+			gotMapWithDollar := tc.in.AsMapWithDollarPrefix()
+
+			for i, got := range gotSlice {
+				want := tc.wantSlice[i]
+				if got != want {
+					t.Fatalf("unexpected value: got %q, want %q", got, want)
+				}
+			}
+			for i, got := range gotMap {
+				want := tc.wantMap[i]
+				if got != want {
+					t.Fatalf("unexpected value: got %q, want %q", got, want)
+				}
+			}
+			for i, got := range gotMapOrdered {
+				want := tc.wantMapOrdered[i]
+				if got != want {
+					t.Fatalf("unexpected value: got %q, want %q", got, want)
+				}
+			}
+			for key, got := range gotMapWithDollar {
+				want := tc.wantMapWithDollar[key]
+				if got != want {
+					t.Fatalf("unexpected value in AsMapWithDollarPrefix: got %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParamManifest(t *testing.T) {
+	optional := false
+	tcs := []struct {
+		name string
+		in   tools.Parameter
+		want tools.ParameterManifest
+	}{
+		{
+			name: "string",
+			in:   tools.NewStringParameter("foo-string", "bar"),
+			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "optional string",
+			in:   &tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "foo-string", Type: "string", Desc: "bar", Required: &optional}},
+			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Required: false, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "int",
+			in:   tools.NewIntParameter("foo-int", "bar"),
+			want: tools.ParameterManifest{Name: "foo-int", Type: "integer", Required: true, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "float",
+			in:   tools.NewFloatParameter("foo-float", "bar"),
+			want: tools.ParameterManifest{Name: "foo-float", Type: "float", Required: true, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "boolean",
+			in:   tools.NewBooleanParameter("foo-bool", "bar"),
+			want: tools.ParameterManifest{Name: "foo-bool", Type: "boolean", Required: true, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "array",
+			in:   tools.NewArrayParameter("foo-array", "bar", tools.NewStringParameter("foo-string", "bar")),
+			want: tools.ParameterManifest{
+				Name:         "foo-array",
+				Type:         "array",
+				Required:     true,
+				Description:  "bar",
+				AuthServices: []string{},
+				Items:        &tools.ParameterManifest{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+			},
+		},
+		{
+			name: "object",
+			in: &tools.ObjectParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-object", Type: "object", Desc: "bar"},
+				Properties: tools.Parameters{
+					tools.NewStringParameter("foo-string", "bar"),
+					tools.NewIntParameterWithDefault("foo-int", 1, "bar"),
+				},
+			},
+			want: tools.ParameterManifest{
+				Name:         "foo-object",
+				Type:         "object",
+				Required:     true,
+				Description:  "bar",
+				AuthServices: []string{},
+				Properties: []tools.ParameterManifest{
+					{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+					{Name: "foo-int", Type: "integer", Required: false, Description: "bar", AuthServices: []string{}},
+				},
+				AdditionalProperties: false,
+			},
+		},
+		{
+			name: "string default",
+			in:   tools.NewStringParameterWithDefault("foo-string", "foo", "bar"),
+			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Required: false, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "int default",
+			in:   tools.NewIntParameterWithDefault("foo-int", 1, "bar"),
+			want: tools.ParameterManifest{Name: "foo-int", Type: "integer", Required: false, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "float default",
+			in:   tools.NewFloatParameterWithDefault("foo-float", 1.1, "bar"),
+			want: tools.ParameterManifest{Name: "foo-float", Type: "float", Required: false, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "boolean default",
+			in:   tools.NewBooleanParameterWithDefault("foo-bool", true, "bar"),
+			want: tools.ParameterManifest{Name: "foo-bool", Type: "boolean", Required: false, Description: "bar", AuthServices: []string{}},
+		},
+		{
+			name: "array default",
+			in:   tools.NewArrayParameterWithDefault("foo-array", `["foo", "bar"]`, "bar", tools.NewStringParameter("foo-string", "bar")),
+			want: tools.ParameterManifest{
+				Name:         "foo-array",
+				Type:         "array",
+				Required:     false,
+				Description:  "bar",
+				AuthServices: []string{},
+				Items:        &tools.ParameterManifest{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+			},
+		},
+	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
 			got := tc.in.Manifest()
@@ -1015,6 +1714,7 @@ func TestParamManifest(t *testing.T) {
 }
 
 func TestParamMcpManifest(t *testing.T) {
+	maxLength, minItems, minimum := 5, 1, 1.0
 	tcs := []struct {
 		name string
 		in   tools.Parameter
@@ -1049,6 +1749,89 @@ func TestParamMcpManifest(t *testing.T) {
 				Items:       &tools.ParameterMcpManifest{Type: "string", Description: "bar"},
 			},
 		},
+		{
+			name: "object",
+			in: &tools.ObjectParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-object", Type: "object", Desc: "bar"},
+				Properties: tools.Parameters{
+					tools.NewStringParameter("foo-string", "bar"),
+					tools.NewArrayParameter("foo-array", "bar", tools.NewStringParameter("foo-string", "bar")),
+				},
+				Required:                   []string{"foo-string"},
+				AdditionalProperties:       true,
+				AdditionalPropertiesSchema: tools.NewIntParameter("foo-int", "bar"),
+			},
+			want: tools.ParameterMcpManifest{
+				Type:        "object",
+				Description: "bar",
+				Properties: map[string]tools.ParameterMcpManifest{
+					"foo-string": {Type: "string", Description: "bar"},
+					"foo-array": {
+						Type:        "array",
+						Description: "bar",
+						Items:       &tools.ParameterMcpManifest{Type: "string", Description: "bar"},
+					},
+				},
+				Required:             []string{"foo-string"},
+				AdditionalProperties: &tools.ParameterMcpManifest{Type: "integer", Description: "bar"},
+			},
+		},
+		{
+			name: "date",
+			in:   tools.NewDateParameter("foo-date", "bar"),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Format: "date"},
+		},
+		{
+			name: "timestamp",
+			in:   tools.NewTimestampParameter("foo-timestamp", "bar"),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Format: "date-time"},
+		},
+		{
+			name: "bytes",
+			in:   tools.NewBytesParameter("foo-bytes", "bar"),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", ContentEncoding: "base64"},
+		},
+		{
+			name: "string constraints",
+			in: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-array", Type: "array", Desc: "bar"},
+				Items: &tools.StringParameter{
+					CommonParameter: tools.CommonParameter{Name: "foo-string", Type: "string", Desc: "bar"},
+					Enum:            []string{"a", "b"},
+					MaxLength:       &maxLength,
+					Pattern:         "^[a-z]$",
+					Format:          "email",
+				},
+				MinItems: &minItems,
+			},
+			want: tools.ParameterMcpManifest{
+				Type:        "array",
+				Description: "bar",
+				Items: &tools.ParameterMcpManifest{
+					Type:        "string",
+					Description: "bar",
+					Enum:        []any{"a", "b"},
+					MaxLength:   &maxLength,
+					Pattern:     "^[a-z]$",
+					Format:      "email",
+				},
+				MinItems: &minItems,
+			},
+		},
+		{
+			name: "int constraints",
+			in: &tools.IntParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-int", Type: "integer", Desc: "bar"},
+				Enum:            []int{1, 2},
+				Minimum:         &minItems,
+			},
+			want: tools.ParameterMcpManifest{
+				Type:        "integer",
+				Description: "bar",
+				Enum:        []any{1, 2},
+				Minimum:     &minimum,
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1061,6 +1844,7 @@ func TestParamMcpManifest(t *testing.T) {
 }
 
 func TestMcpManifest(t *testing.T) {
+	optional := false
 	tcs := []struct {
 		name string
 		in   tools.Parameters
@@ -1097,6 +1881,38 @@ func TestMcpManifest(t *testing.T) {
 				Required: []string{"foo-string2", "foo-int2", "foo-array2"},
 			},
 		},
+		{
+			name: "optional",
+			in: tools.Parameters{
+				tools.NewStringParameter("foo-string", "bar"),
+				&tools.StringParameter{CommonParameter: tools.CommonParameter{Name: "foo-string2", Type: "string", Desc: "bar", Required: &optional}},
+				&tools.ObjectParameter{
+					CommonParameter: tools.CommonParameter{Name: "foo-object", Type: "object", Desc: "bar", Required: &optional},
+					Properties: tools.Parameters{
+						tools.NewStringParameter("foo-string", "bar"),
+						&tools.IntParameter{CommonParameter: tools.CommonParameter{Name: "foo-int", Type: "integer", Desc: "bar", Required: &optional}},
+					},
+				},
+			},
+			want: tools.McpToolsSchema{
+				Type: "object",
+				Properties: map[string]tools.ParameterMcpManifest{
+					"foo-string":  {Type: "string", Description: "bar"},
+					"foo-string2": {Type: "string", Description: "bar"},
+					"foo-object": {
+						Type:        "object",
+						Description: "bar",
+						Properties: map[string]tools.ParameterMcpManifest{
+							"foo-string": {Type: "string", Description: "bar"},
+							"foo-int":    {Type: "integer", Description: "bar"},
+						},
+						Required:             []string{"foo-string"},
+						AdditionalProperties: false,
+					},
+				},
+				Required: []string{"foo-string"},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1174,6 +1990,102 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "unable to parse as \"array\": unable to parse 'items' field: unable to parse as \"string\": Key: 'CommonParameter.Name' Error:Field validation for 'Name' failed on the 'required' tag",
 		},
+		{
+			name: "object parameter with unknown required property",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "my_string",
+							"type":        "string",
+							"description": "string property",
+						},
+					},
+					"required": []string{"my_int"},
+				},
+			},
+			err: "unable to parse as \"object\": required property \"my_int\" is not listed in 'properties'",
+		},
+		{
+			name: "object parameter with authenticated property",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "my_string",
+							"type":        "string",
+							"description": "string property",
+							"authServices": []map[string]string{
+								{"name": "my-google-auth", "field": "email"},
+							},
+						},
+					},
+				},
+			},
+			err: "unable to parse as \"object\": nested properties should not have auth services",
+		},
+		{
+			name: "object with invalid required list",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "my_string",
+							"type":        "string",
+							"description": "string property",
+						},
+					},
+					"required": []any{1},
+				},
+			},
+			err: "unable to parse as \"object\": 'required' must be a bool or a list of property names",
+		},
+		{
+			name: "invalid pattern",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"pattern":     "[a-z",
+				},
+			},
+			err: "invalid constraints for \"my_string\": invalid pattern \"[a-z\": error parsing regexp: missing closing ]: `[a-z`",
+		},
+		{
+			name: "unknown format",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"format":      "ipv4",
+				},
+			},
+			err: "invalid constraints for \"my_string\": unknown format \"ipv4\": must be one of [\"date\" \"date-time\" \"uuid\" \"email\"]",
+		},
+		{
+			name: "minimum greater than maximum",
+			in: []map[string]any{
+				{
+					"name":        "my_integer",
+					"type":        "integer",
+					"description": "this param is an int",
+					"minimum":     10,
+					"maximum":     1,
+				},
+			},
+			err: "invalid constraints for \"my_integer\": minimum 10 is greater than maximum 1",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1375,65 +2287,115 @@ func TestFailGetParams(t *testing.T) {
 }
 
 func TestResolveTemplateParameters(t *testing.T) {
+	tableName := &tools.TemplateParameter{
+		Parameter:  tools.NewStringParameter("tableName", "this is a string template parameter"),
+		Identifier: true,
+	}
 	tcs := []struct {
 		name           string
 		templateParams tools.Parameters
+		dialect        tools.Dialect
 		statement      string
 		in             map[string]any
 		want           string
 	}{
 		{
-			name: "single template parameter",
-			templateParams: tools.Parameters{
-				tools.NewStringParameter("tableName", "this is a string template parameter"),
-			},
-			statement: "SELECT * FROM {{.tableName}}",
+			name:           "single template parameter",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{.tableName}}",
 			in: map[string]any{
 				"tableName": "hotels",
 			},
-			want: "SELECT * FROM hotels",
+			want: `SELECT * FROM "hotels"`,
 		},
 		{
 			name: "multiple template parameters",
 			templateParams: tools.Parameters{
-				tools.NewStringParameter("tableName", "this is a string template parameter"),
-				tools.NewStringParameter("columnName", "this is a string template parameter"),
+				tableName,
+				&tools.TemplateParameter{
+					Parameter:     tools.NewStringParameter("columnName", "this is a string template parameter"),
+					AllowedValues: []string{"name", "location"},
+				},
 			},
+			dialect:   tools.DialectMySQL,
 			statement: "SELECT * FROM {{.tableName}} WHERE {{.columnName}} = 'Hilton'",
 			in: map[string]any{
 				"tableName":  "hotels",
 				"columnName": "name",
 			},
-			want: "SELECT * FROM hotels WHERE name = 'Hilton'",
+			want: "SELECT * FROM `hotels` WHERE name = 'Hilton'",
 		},
 		{
-			name: "standard and template parameter",
-			templateParams: tools.Parameters{
-				tools.NewStringParameter("tableName", "this is a string template parameter"),
-				tools.NewStringParameter("hotelName", "this is a string parameter"),
-			},
-			statement: "SELECT * FROM {{.tableName}} WHERE name = $1",
+			name:           "standard and template parameter",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectMSSQL,
+			statement:      "SELECT * FROM {{.tableName}} WHERE name = @p1",
 			in: map[string]any{
 				"tableName": "hotels",
 				"hotelName": "name",
 			},
+			want: "SELECT * FROM [hotels] WHERE name = @p1",
+		},
+		{
+			name:           "standard parameter",
+			templateParams: tools.Parameters{},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM hotels WHERE name = $1",
+			in: map[string]any{
+				"hotelName": "hotels",
+			},
 			want: "SELECT * FROM hotels WHERE name = $1",
 		},
 		{
-			name: "standard parameter",
+			name: "array of identifiers",
 			templateParams: tools.Parameters{
-				tools.NewStringParameter("hotelName", "this is a string parameter"),
+				&tools.TemplateParameter{
+					Parameter:  tools.NewArrayParameter("columns", "this is an array template parameter", tools.NewStringParameter("column", "a column")),
+					Identifier: true,
+				},
 			},
-			statement: "SELECT * FROM hotels WHERE name = $1",
+			dialect:   tools.DialectGoogleSQL,
+			statement: "SELECT {{array .columns}} FROM hotels",
 			in: map[string]any{
-				"hotelName": "hotels",
+				"columns": []any{"id", "my`name"},
 			},
-			want: "SELECT * FROM hotels WHERE name = $1",
+			want: "SELECT `id`, `my\\`name` FROM hotels",
+		},
+		{
+			name: "pattern",
+			templateParams: tools.Parameters{
+				&tools.TemplateParameter{
+					Parameter:      tools.NewStringParameter("order", "this is a string template parameter"),
+					AllowedPattern: "(ASC|DESC)",
+				},
+			},
+			dialect:   tools.DialectSQLite,
+			statement: "SELECT * FROM hotels ORDER BY id {{.order}}",
+			in: map[string]any{
+				"order": "DESC",
+			},
+			want: "SELECT * FROM hotels ORDER BY id DESC",
+		},
+		{
+			name: "unvalidated integer",
+			templateParams: tools.Parameters{
+				tools.NewIntParameter("limit", "this is an int template parameter"),
+			},
+			dialect:   tools.DialectPostgres,
+			statement: "SELECT * FROM hotels LIMIT {{.limit}}",
+			in: map[string]any{
+				"limit": 10,
+			},
+			want: "SELECT * FROM hotels LIMIT 10",
 		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			got, _ := tools.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in)
+			got, err := tools.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Fatalf("incorrect resolved template params: diff %v", diff)
 			}
@@ -1442,59 +2404,345 @@ func TestResolveTemplateParameters(t *testing.T) {
 }
 
 func TestFailResolveTemplateParameters(t *testing.T) {
+	tableName := &tools.TemplateParameter{
+		Parameter:  tools.NewStringParameter("tableName", "this is a string template parameter"),
+		Identifier: true,
+	}
 	tcs := []struct {
 		name           string
 		templateParams tools.Parameters
+		dialect        tools.Dialect
 		statement      string
 		in             map[string]any
 		err            string
 	}{
 		{
-			name: "wrong param name",
-			templateParams: tools.Parameters{
-				tools.NewStringParameter("tableName", "this is a string template parameter"),
+			name:           "wrong param name",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{.missingParam}}",
+			in:             map[string]any{},
+			err:            "error getting template params missing parameter tableName",
+		},
+		{
+			name:           "incomplete param template",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{.tableName",
+			in: map[string]any{
+				"tableName": "hotels",
 			},
-			statement: "SELECT * FROM {{.missingParam}}",
-			in:        map[string]any{},
-			err:       "error getting template params missing parameter tableName",
+			err: "error creating go template template: statement:1: unclosed action",
 		},
 		{
-			name: "incomplete param template",
-			templateParams: tools.Parameters{
-				tools.NewStringParameter("tableName", "this is a string template parameter"),
+			name:           "undefined function",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{json .tableName}}",
+			in: map[string]any{
+				"tableName": "hotels",
 			},
-			statement: "SELECT * FROM {{.tableName",
+			err: "error creating go template template: statement:1: function \"json\" not defined",
+		},
+		{
+			name:           "undefined method",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{.tableName .wrong}}",
 			in: map[string]any{
 				"tableName": "hotels",
 			},
-			err: "error creating go template template: statement:1: unclosed action",
+			err: "error executing go template template: statement:1:16: executing \"statement\" at <.tableName>: tableName is not a method but has arguments",
 		},
 		{
-			name: "undefined function",
+			name: "unvalidated string",
 			templateParams: tools.Parameters{
 				tools.NewStringParameter("tableName", "this is a string template parameter"),
 			},
-			statement: "SELECT * FROM {{json .tableName}}",
+			dialect:   tools.DialectPostgres,
+			statement: "SELECT * FROM {{.tableName}}",
+			in: map[string]any{
+				"tableName": "hotels; DROP TABLE hotels",
+			},
+			err: "invalid value for template parameter \"tableName\": strings must be validated by allowedValues, allowedPattern or identifier",
+		},
+		{
+			name:           "null value",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{.tableName}}",
 			in: map[string]any{
-				"tableName": "hotels",
+				"tableName": nil,
 			},
-			err: "error creating go template template: statement:1: function \"json\" not defined",
+			err: "invalid value for template parameter \"tableName\": a value is required",
 		},
 		{
-			name: "undefined method",
+			name: "value not allowed",
 			templateParams: tools.Parameters{
-				tools.NewStringParameter("tableName", "this is a string template parameter"),
+				&tools.TemplateParameter{
+					Parameter:     tools.NewStringParameter("columnName", "this is a string template parameter"),
+					AllowedValues: []string{"name", "location"},
+				},
 			},
-			statement: "SELECT * FROM {{.tableName .wrong}}",
+			dialect:   tools.DialectPostgres,
+			statement: "SELECT {{.columnName}} FROM hotels",
+			in: map[string]any{
+				"columnName": "password",
+			},
+			err: "invalid value for template parameter \"columnName\": violates \"allowedValues\" constraint: \"password\" is not one of [\"name\" \"location\"]",
+		},
+		{
+			name: "pattern matches part of the value",
+			templateParams: tools.Parameters{
+				&tools.TemplateParameter{
+					Parameter:      tools.NewStringParameter("order", "this is a string template parameter"),
+					AllowedPattern: "ASC|DESC",
+				},
+			},
+			dialect:   tools.DialectPostgres,
+			statement: "SELECT * FROM hotels ORDER BY id {{.order}}",
+			in: map[string]any{
+				"order": "ASC; DROP TABLE hotels",
+			},
+			err: "invalid value for template parameter \"order\": violates \"allowedPattern\" constraint: \"ASC; DROP TABLE hotels\" doesn't match \"ASC|DESC\"",
+		},
+		{
+			name:           "empty identifier",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.DialectPostgres,
+			statement:      "SELECT * FROM {{.tableName}}",
+			in: map[string]any{
+				"tableName": "",
+			},
+			err: "invalid value for template parameter \"tableName\": violates \"identifier\" constraint: identifier can't be empty",
+		},
+		{
+			name:           "unknown dialect",
+			templateParams: tools.Parameters{tableName},
+			dialect:        tools.Dialect("oracle"),
+			statement:      "SELECT * FROM {{.tableName}}",
 			in: map[string]any{
 				"tableName": "hotels",
 			},
-			err: "error executing go template template: statement:1:16: executing \"statement\" at <.tableName>: tableName is not a method but has arguments",
+			err: "invalid value for template parameter \"tableName\": unable to quote identifiers of dialect \"oracle\"",
 		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := tools.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in)
+			_, err := tools.ResolveTemplateParams(tc.templateParams, tc.statement, tc.in, tc.dialect)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	tcs := []struct {
+		dialect tools.Dialect
+		in      string
+		want    string
+	}{
+		{dialect: tools.DialectPostgres, in: `my "table"`, want: `"my ""table"""`},
+		{dialect: tools.DialectSQLite, in: `hotels`, want: `"hotels"`},
+		{dialect: tools.DialectMySQL, in: "my `table`", want: "`my ``table```"},
+		{dialect: tools.DialectSQLPlusPlus, in: "hotels", want: "`hotels`"},
+		{dialect: tools.DialectMSSQL, in: "my [table]", want: "[my [table]]]"},
+		{dialect: tools.DialectGoogleSQL, in: "my`table\\", want: "`my\\`table\\\\`"},
+		{dialect: tools.DialectGoogleSQL, in: "dataset.hotels", want: "`dataset.hotels`"},
+	}
+	for _, tc := range tcs {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			got, err := tools.QuoteIdentifier(tc.dialect, tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected identifier: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTemplateParametersUnmarshal(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+		- name: tableName
+		  type: string
+		  description: the table
+		  identifier: true
+		- name: columns
+		  type: array
+		  description: the columns
+		  allowedValues: ["id", "name"]
+		  items:
+		    name: column
+		    type: string
+		    description: a column
+		- name: order
+		  type: string
+		  description: the order
+		  allowedPattern: "ASC|DESC"
+		- name: limit
+		  type: integer
+		  description: the limit
+	`
+	want := tools.TemplateParameters{
+		&tools.TemplateParameter{
+			Parameter:  tools.NewStringParameter("tableName", "the table"),
+			Identifier: true,
+		},
+		&tools.TemplateParameter{
+			Parameter:     tools.NewArrayParameter("columns", "the columns", tools.NewStringParameter("column", "a column")),
+			AllowedValues: []string{"id", "name"},
+		},
+		&tools.TemplateParameter{
+			Parameter:      tools.NewStringParameter("order", "the order"),
+			AllowedPattern: "ASC|DESC",
+		},
+		&tools.TemplateParameter{
+			Parameter: tools.NewIntParameter("limit", "the limit"),
+		},
+	}
+	var got tools.TemplateParameters
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+
+	// the validation fields round trip through yaml
+	data, err := yaml.Marshal(want)
+	if err != nil {
+		t.Fatalf("unable to marshal: %s", err)
+	}
+	got = nil
+	if err := yaml.UnmarshalContext(ctx, data, &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Fatalf("incorrect round trip: diff %v", diff)
+	}
+
+	// values are validated when parsed
+	if _, err := got[1].Parse([]any{"id", "password"}); err == nil {
+		t.Fatalf("expected parsing to fail")
+	}
+	if _, err := got[2].Parse("ASC"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantMcp := tools.ParameterMcpManifest{
+		Type:        "array",
+		Description: "the columns",
+		Items: &tools.ParameterMcpManifest{
+			Type:        "string",
+			Description: "a column",
+			Enum:        []any{"id", "name"},
+		},
+	}
+	if diff := cmp.Diff(wantMcp, got[1].McpManifest()); diff != "" {
+		t.Fatalf("incorrect mcp manifest: diff %v", diff)
+	}
+	if pattern := got[2].McpManifest().Pattern; pattern != "^(?:ASC|DESC)$" {
+		t.Fatalf("unexpected pattern: %s", pattern)
+	}
+}
+
+func TestFailTemplateParametersUnmarshal(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		name string
+		in   map[string]any
+		err  string
+	}{
+		{
+			name: "unvalidated string",
+			in: map[string]any{
+				"name":        "tableName",
+				"type":        "string",
+				"description": "the table",
+			},
+			err: "template parameter \"tableName\" must set one of allowedValues, allowedPattern or identifier",
+		},
+		{
+			name: "unvalidated string array",
+			in: map[string]any{
+				"name":        "columns",
+				"type":        "array",
+				"description": "the columns",
+				"items": map[string]any{
+					"name":        "column",
+					"type":        "string",
+					"description": "a column",
+				},
+			},
+			err: "template parameter \"columns\" must set one of allowedValues, allowedPattern or identifier",
+		},
+		{
+			name: "validated integer",
+			in: map[string]any{
+				"name":        "limit",
+				"type":        "integer",
+				"description": "the limit",
+				"identifier":  true,
+			},
+			err: "template parameter \"limit\": allowedValues, allowedPattern and identifier only apply to strings",
+		},
+		{
+			name: "object",
+			in: map[string]any{
+				"name":        "filter",
+				"type":        "object",
+				"description": "the filter",
+				"properties":  []any{},
+			},
+			err: "\"object\" is not a valid type for template parameter \"filter\"",
+		},
+		{
+			name: "optional",
+			in: map[string]any{
+				"name":        "tableName",
+				"type":        "string",
+				"description": "the table",
+				"identifier":  true,
+				"required":    false,
+			},
+			err: "template parameter \"tableName\" must be required or have a default",
+		},
+		{
+			name: "invalid pattern",
+			in: map[string]any{
+				"name":           "order",
+				"type":           "string",
+				"description":    "the order",
+				"allowedPattern": "(ASC",
+			},
+			err: "template parameter \"order\": invalid pattern \"^(?:(ASC)$\": error parsing regexp: missing closing ): `^(?:(ASC)$`",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := yaml.Marshal([]any{tc.in})
+			if err != nil {
+				t.Fatalf("unable to marshal input to yaml: %s", err)
+			}
+			var got tools.TemplateParameters
+			err = yaml.UnmarshalContext(ctx, data, &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
 			errStr := err.Error()
 			if errStr != tc.err {
 				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
@@ -0,0 +1,338 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrPolicyDenied is returned when the claims of a caller don't satisfy the
+// policy of a tool or toolset.
+var ErrPolicyDenied = errors.New("denied by policy")
+
+// Policy restricts the callers of a tool or toolset based on the claims
+// verified by their auth services. A caller is allowed if it matches every
+// rule of AllOf, and at least one rule of AnyOf when AnyOf is set.
+type Policy struct {
+	AllOf []PolicyRule `yaml:"allOf"`
+	AnyOf []PolicyRule `yaml:"anyOf"`
+}
+
+// PolicyRule matches a single claim against exactly one condition.
+type PolicyRule struct {
+	// Name of the auth service the claim must come from. If not set, the
+	// claims of every verified auth service are checked.
+	AuthService string `yaml:"authService"`
+	// Name of the claim. Nested claims are separated with dots, e.g.
+	// `realm_access.roles`.
+	Claim string `yaml:"claim" validate:"required"`
+	// The claim is equal to the value.
+	Equals any `yaml:"equals"`
+	// The claim is equal to one of the values.
+	In []any `yaml:"in"`
+	// The claim is a list that contains the value, or a string that
+	// contains the value as a substring.
+	Contains any `yaml:"contains"`
+	// The claim is a string that starts with the value.
+	StartsWith string `yaml:"startsWith"`
+	// The claim is a string that ends with the value.
+	EndsWith string `yaml:"endsWith"`
+	// The claim is a string that fully matches the regular expression.
+	Matches string `yaml:"matches"`
+}
+
+// claimMatcher reports whether the value of a claim satisfies a rule.
+type claimMatcher func(v any) bool
+
+type compiledRule struct {
+	authService string
+	path        []string
+	match       claimMatcher
+}
+
+type compiledPolicy struct {
+	allOf []compiledRule
+	anyOf []compiledRule
+}
+
+func compileRule(r PolicyRule) (compiledRule, error) {
+	if r.Claim == "" {
+		return compiledRule{}, fmt.Errorf("claim must be set for every rule")
+	}
+	var matchers []claimMatcher
+	if r.Equals != nil {
+		matchers = append(matchers, func(v any) bool { return claimEqual(v, r.Equals) })
+	}
+	if r.In != nil {
+		matchers = append(matchers, func(v any) bool {
+			for _, want := range r.In {
+				if claimEqual(v, want) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	if r.Contains != nil {
+		matchers = append(matchers, func(v any) bool {
+			switch v := v.(type) {
+			case []any:
+				for _, e := range v {
+					if claimEqual(e, r.Contains) {
+						return true
+					}
+				}
+			case string:
+				s, ok := r.Contains.(string)
+				return ok && strings.Contains(v, s)
+			}
+			return false
+		})
+	}
+	if r.StartsWith != "" {
+		matchers = append(matchers, func(v any) bool {
+			s, ok := v.(string)
+			return ok && strings.HasPrefix(s, r.StartsWith)
+		})
+	}
+	if r.EndsWith != "" {
+		matchers = append(matchers, func(v any) bool {
+			s, ok := v.(string)
+			return ok && strings.HasSuffix(s, r.EndsWith)
+		})
+	}
+	if r.Matches != "" {
+		re, err := regexp.Compile("^(?:" + r.Matches + ")$")
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("invalid regular expression for claim %q: %w", r.Claim, err)
+		}
+		matchers = append(matchers, func(v any) bool {
+			s, ok := v.(string)
+			return ok && re.MatchString(s)
+		})
+	}
+	if len(matchers) != 1 {
+		return compiledRule{}, fmt.Errorf("rule for claim %q must set exactly one of equals, in, contains, startsWith, endsWith or matches", r.Claim)
+	}
+	return compiledRule{
+		authService: r.AuthService,
+		path:        strings.Split(r.Claim, "."),
+		match:       matchers[0],
+	}, nil
+}
+
+func compilePolicy(p *Policy) (*compiledPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if len(p.AllOf) == 0 && len(p.AnyOf) == 0 {
+		return nil, fmt.Errorf("at least one rule must be set in allOf or anyOf")
+	}
+	c := &compiledPolicy{}
+	for _, r := range p.AllOf {
+		cr, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		c.allOf = append(c.allOf, cr)
+	}
+	for _, r := range p.AnyOf {
+		cr, err := compileRule(r)
+		if err != nil {
+			return nil, err
+		}
+		c.anyOf = append(c.anyOf, cr)
+	}
+	return c, nil
+}
+
+// claimEqual compares a claim with a value from the config. Numbers are
+// compared by value, since JSON claims are decoded as float64 and YAML values
+// as integers.
+func claimEqual(claim, want any) bool {
+	if c, ok := toFloat(claim); ok {
+		w, ok := toFloat(want)
+		return ok && c == w
+	}
+	return reflect.DeepEqual(claim, want)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// lookupClaim returns the value of a claim, following nested objects.
+func lookupClaim(claims map[string]any, path []string) (any, bool) {
+	var v any = claims
+	for _, p := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		v, ok = m[p]
+		if !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (r compiledRule) allows(claimsFromAuth map[string]map[string]any) bool {
+	for name, claims := range claimsFromAuth {
+		if r.authService != "" && r.authService != name {
+			continue
+		}
+		if v, ok := lookupClaim(claims, r.path); ok && r.match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *compiledPolicy) allows(claimsFromAuth map[string]map[string]any) bool {
+	if p == nil {
+		return true
+	}
+	for _, r := range p.allOf {
+		if !r.allows(claimsFromAuth) {
+			return false
+		}
+	}
+	if len(p.anyOf) == 0 {
+		return true
+	}
+	for _, r := range p.anyOf {
+		if r.allows(claimsFromAuth) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorizer evaluates the policies of tools and toolsets against the claims
+// of a caller. It is shared by every endpoint that lists or invokes tools. A
+// nil Authorizer allows every caller.
+type Authorizer struct {
+	tools    map[string]*compiledPolicy
+	toolsets map[string]*compiledPolicy
+	// toolToolsets are the names of the toolsets with a policy that contain
+	// each tool
+	toolToolsets map[string][]string
+}
+
+// NewAuthorizer validates the policies of tools and toolsets, mapped by their
+// names, and returns an Authorizer that evaluates them.
+func NewAuthorizer(toolPolicies map[string]*Policy, toolsets map[string]ToolsetConfig) (*Authorizer, error) {
+	a := &Authorizer{
+		tools:        make(map[string]*compiledPolicy),
+		toolsets:     make(map[string]*compiledPolicy),
+		toolToolsets: make(map[string][]string),
+	}
+	for name, p := range toolPolicies {
+		c, err := compilePolicy(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy for tool %q: %w", name, err)
+		}
+		if c != nil {
+			a.tools[name] = c
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(toolsets)) {
+		c, err := compilePolicy(toolsets[name].Policy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy for toolset %q: %w", name, err)
+		}
+		if c == nil {
+			continue
+		}
+		a.toolsets[name] = c
+		for _, toolName := range toolsets[name].ToolNames {
+			a.toolToolsets[toolName] = append(a.toolToolsets[toolName], name)
+		}
+	}
+	return a, nil
+}
+
+// Authorize returns an error wrapping ErrPolicyDenied if the claims don't
+// satisfy the policy of the toolset or of the tool. Since a tool can be
+// invoked through any toolset that contains it, e.g. the default toolset, the
+// policies of all the toolsets that contain the tool apply too. It doesn't
+// check the `authRequired` field of the tool, see Tool.Authorized.
+func (a *Authorizer) Authorize(toolsetName, toolName string, claimsFromAuth map[string]map[string]any) error {
+	if a == nil {
+		return nil
+	}
+	if !a.toolsets[toolsetName].allows(claimsFromAuth) {
+		return fmt.Errorf("%w: the policy of toolset %q is not satisfied", ErrPolicyDenied, toolsetName)
+	}
+	for _, name := range a.toolToolsets[toolName] {
+		if !a.toolsets[name].allows(claimsFromAuth) {
+			return fmt.Errorf("%w: the policy of toolset %q is not satisfied", ErrPolicyDenied, name)
+		}
+	}
+	if !a.tools[toolName].allows(claimsFromAuth) {
+		return fmt.Errorf("%w: the policy of tool %q is not satisfied", ErrPolicyDenied, toolName)
+	}
+	return nil
+}
+
+// ToolsetManifest returns the manifest of the toolset, without the tools that
+// the caller isn't allowed to use.
+func (a *Authorizer) ToolsetManifest(toolset Toolset, claimsFromAuth map[string]map[string]any) ToolsetManifest {
+	if a == nil {
+		return toolset.Manifest
+	}
+	m := ToolsetManifest{
+		ServerVersion: toolset.Manifest.ServerVersion,
+		ToolsManifest: make(map[string]Manifest),
+	}
+	for name, tm := range toolset.Manifest.ToolsManifest {
+		if a.Authorize(toolset.Name, name, claimsFromAuth) == nil {
+			m.ToolsManifest[name] = tm
+		}
+	}
+	return m
+}
+
+// McpManifest returns the MCP manifests of the toolset, without the tools
+// that the caller isn't allowed to use.
+func (a *Authorizer) McpManifest(toolset Toolset, claimsFromAuth map[string]map[string]any) []McpManifest {
+	if a == nil {
+		return toolset.McpManifest
+	}
+	var m []McpManifest
+	for _, tm := range toolset.McpManifest {
+		if a.Authorize(toolset.Name, tm.Name, claimsFromAuth) == nil {
+			m = append(m, tm)
+		}
+	}
+	return m
+}
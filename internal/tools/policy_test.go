@@ -0,0 +1,247 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestAuthorize(t *testing.T) {
+	toolPolicies := map[string]*tools.Policy{
+		"analytics_tool": {
+			AnyOf: []tools.PolicyRule{
+				{Claim: "groups", Contains: "analytics"},
+				{Claim: "email", EndsWith: "@corp.com"},
+			},
+		},
+		"admin_tool": {
+			AllOf: []tools.PolicyRule{
+				{AuthService: "my-google", Claim: "realm_access.roles", Contains: "admin"},
+				{Claim: "level", In: []any{uint64(3), uint64(4)}},
+			},
+		},
+		"regex_tool": {
+			AllOf: []tools.PolicyRule{{Claim: "sub", Matches: "user-[0-9]+"}},
+		},
+		"open_tool": nil,
+	}
+	toolsets := map[string]tools.ToolsetConfig{
+		"": {
+			ToolNames: []string{"analytics_tool", "admin_tool", "regex_tool", "open_tool", "corp_tool"},
+		},
+		"corp_toolset": {
+			Name:      "corp_toolset",
+			ToolNames: []string{"corp_tool"},
+			Policy: &tools.Policy{
+				AllOf: []tools.PolicyRule{{Claim: "hd", Equals: "corp.com"}},
+			},
+		},
+	}
+	a, err := tools.NewAuthorizer(toolPolicies, toolsets)
+	if err != nil {
+		t.Fatalf("unable to create authorizer: %s", err)
+	}
+
+	tcs := []struct {
+		name    string
+		toolset string
+		tool    string
+		claims  map[string]map[string]any
+		allowed bool
+	}{
+		{
+			name:    "tool without policy",
+			tool:    "open_tool",
+			claims:  map[string]map[string]any{},
+			allowed: true,
+		},
+		{
+			name:    "list contains value",
+			tool:    "analytics_tool",
+			claims:  map[string]map[string]any{"my-oidc": {"groups": []any{"sales", "analytics"}}},
+			allowed: true,
+		},
+		{
+			name:    "string ends with value",
+			tool:    "analytics_tool",
+			claims:  map[string]map[string]any{"my-oidc": {"email": "jane@corp.com"}},
+			allowed: true,
+		},
+		{
+			name:    "no rule of anyOf matches",
+			tool:    "analytics_tool",
+			claims:  map[string]map[string]any{"my-oidc": {"groups": []any{"sales"}, "email": "jane@example.com"}},
+			allowed: false,
+		},
+		{
+			name:    "no claims",
+			tool:    "analytics_tool",
+			claims:  map[string]map[string]any{},
+			allowed: false,
+		},
+		{
+			name:    "nested claim and number in list",
+			tool:    "admin_tool",
+			claims:  map[string]map[string]any{"my-google": {"realm_access": map[string]any{"roles": []any{"admin"}}, "level": float64(4)}},
+			allowed: true,
+		},
+		{
+			name:    "claim from another auth service",
+			tool:    "admin_tool",
+			claims:  map[string]map[string]any{"my-oidc": {"realm_access": map[string]any{"roles": []any{"admin"}}, "level": float64(4)}},
+			allowed: false,
+		},
+		{
+			name:    "one rule of allOf doesn't match",
+			tool:    "admin_tool",
+			claims:  map[string]map[string]any{"my-google": {"realm_access": map[string]any{"roles": []any{"admin"}}, "level": float64(2)}},
+			allowed: false,
+		},
+		{
+			name:    "regular expression matches",
+			tool:    "regex_tool",
+			claims:  map[string]map[string]any{"my-oidc": {"sub": "user-123"}},
+			allowed: true,
+		},
+		{
+			name:    "regular expression must match the whole claim",
+			tool:    "regex_tool",
+			claims:  map[string]map[string]any{"my-oidc": {"sub": "user-123-admin"}},
+			allowed: false,
+		},
+		{
+			name:    "toolset policy matches",
+			toolset: "corp_toolset",
+			tool:    "corp_tool",
+			claims:  map[string]map[string]any{"my-google": {"hd": "corp.com"}},
+			allowed: true,
+		},
+		{
+			name:    "toolset policy doesn't match",
+			toolset: "corp_toolset",
+			tool:    "corp_tool",
+			claims:  map[string]map[string]any{"my-google": {"hd": "example.com"}},
+			allowed: false,
+		},
+		{
+			name:    "toolset policy applies through the default toolset",
+			tool:    "corp_tool",
+			claims:  map[string]map[string]any{"my-google": {"hd": "example.com"}},
+			allowed: false,
+		},
+		{
+			name:    "toolset policy matches through the default toolset",
+			tool:    "corp_tool",
+			claims:  map[string]map[string]any{"my-google": {"hd": "corp.com"}},
+			allowed: true,
+		},
+		{
+			name:    "toolset and tool policies must both match",
+			toolset: "corp_toolset",
+			tool:    "analytics_tool",
+			claims:  map[string]map[string]any{"my-google": {"hd": "corp.com", "email": "jane@example.com"}},
+			allowed: false,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := a.Authorize(tc.toolset, tc.tool, tc.claims)
+			if tc.allowed && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.allowed && !errors.Is(err, tools.ErrPolicyDenied) {
+				t.Fatalf("expected ErrPolicyDenied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizerToolsetManifest(t *testing.T) {
+	a, err := tools.NewAuthorizer(map[string]*tools.Policy{
+		"restricted": {AllOf: []tools.PolicyRule{{Claim: "groups", Contains: "admins"}}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create authorizer: %s", err)
+	}
+	toolset := tools.Toolset{
+		Name: "my_toolset",
+		Manifest: tools.ToolsetManifest{
+			ServerVersion: "0.0.0",
+			ToolsManifest: map[string]tools.Manifest{
+				"open":       {Description: "open"},
+				"restricted": {Description: "restricted"},
+			},
+		},
+		McpManifest: []tools.McpManifest{{Name: "open"}, {Name: "restricted"}},
+	}
+	claims := map[string]map[string]any{"my-oidc": {"groups": []any{"users"}}}
+
+	wantManifest := tools.ToolsetManifest{
+		ServerVersion: "0.0.0",
+		ToolsManifest: map[string]tools.Manifest{"open": {Description: "open"}},
+	}
+	if diff := cmp.Diff(wantManifest, a.ToolsetManifest(toolset, claims)); diff != "" {
+		t.Fatalf("incorrect toolset manifest: diff %v", diff)
+	}
+	wantMcp := []tools.McpManifest{{Name: "open"}}
+	if diff := cmp.Diff(wantMcp, a.McpManifest(toolset, claims)); diff != "" {
+		t.Fatalf("incorrect mcp manifest: diff %v", diff)
+	}
+
+	// a nil authorizer allows every caller
+	var nilAuthorizer *tools.Authorizer
+	if diff := cmp.Diff(toolset.McpManifest, nilAuthorizer.McpManifest(toolset, claims)); diff != "" {
+		t.Fatalf("incorrect mcp manifest: diff %v", diff)
+	}
+}
+
+func TestFailNewAuthorizer(t *testing.T) {
+	tcs := []struct {
+		name   string
+		policy *tools.Policy
+	}{
+		{
+			name:   "no rules",
+			policy: &tools.Policy{},
+		},
+		{
+			name:   "missing claim",
+			policy: &tools.Policy{AllOf: []tools.PolicyRule{{Equals: "x"}}},
+		},
+		{
+			name:   "no condition",
+			policy: &tools.Policy{AllOf: []tools.PolicyRule{{Claim: "email"}}},
+		},
+		{
+			name:   "two conditions",
+			policy: &tools.Policy{AnyOf: []tools.PolicyRule{{Claim: "email", Equals: "a@corp.com", EndsWith: "@corp.com"}}},
+		},
+		{
+			name:   "invalid regular expression",
+			policy: &tools.Policy{AllOf: []tools.PolicyRule{{Claim: "sub", Matches: "user-("}}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tools.NewAuthorizer(map[string]*tools.Policy{"my_tool": tc.policy}, nil); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
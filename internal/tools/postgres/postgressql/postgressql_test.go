@@ -74,6 +74,35 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with title and result schema",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					title: Example Tool
+					description: some description
+					resultSchema:
+						type: array
+						items:
+							type: object
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					Name:         "example_tool",
+					Kind:         "postgres-sql",
+					Source:       "my-pg-instance",
+					Title:        "Example Tool",
+					Description:  "some description",
+					ResultSchema: map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+					Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -121,9 +150,11 @@ func TestParseFromYamlWithTemplateParamsPostgres(t *testing.T) {
 						- name: tableName
 						  type: string
 						  description: The table to select hotels from.
+						  identifier: true
 						- name: fieldArray
 						  type: array
 						  description: The columns to return for the query.
+						  identifier: true
 						  items: 
 								name: column
 								type: string
@@ -140,9 +171,15 @@ func TestParseFromYamlWithTemplateParamsPostgres(t *testing.T) {
 					Parameters: []tools.Parameter{
 						tools.NewStringParameter("name", "some description"),
 					},
-					TemplateParameters: []tools.Parameter{
-						tools.NewStringParameter("tableName", "The table to select hotels from."),
-						tools.NewArrayParameter("fieldArray", "The columns to return for the query.", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+					TemplateParameters: tools.TemplateParameters{
+						&tools.TemplateParameter{
+							Parameter:  tools.NewStringParameter("tableName", "The table to select hotels from."),
+							Identifier: true,
+						},
+						&tools.TemplateParameter{
+							Parameter:  tools.NewArrayParameter("fieldArray", "The columns to return for the query.", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+							Identifier: true,
+						},
 					},
 				},
 			},
@@ -51,13 +51,17 @@ var _ compatibleSource = &redissrc.Source{}
 var compatibleSources = [...]string{redissrc.SourceKind}
 
 type Config struct {
-	Name         string           `yaml:"name" validate:"required"`
-	Kind         string           `yaml:"kind" validate:"required"`
-	Source       string           `yaml:"source" validate:"required"`
-	Description  string           `yaml:"description" validate:"required"`
-	Commands     [][]string       `yaml:"commands" validate:"required"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name         string                 `yaml:"name" validate:"required"`
+	Kind         string                 `yaml:"kind" validate:"required"`
+	Source       string                 `yaml:"source" validate:"required"`
+	Description  string                 `yaml:"description" validate:"required"`
+	Title        string                 `yaml:"title"`
+	ResultSchema map[string]any         `yaml:"resultSchema"`
+	Annotations  *tools.ToolAnnotations `yaml:"annotations"`
+	Policy       *tools.Policy          `yaml:"policy"`
+	Commands     [][]string             `yaml:"commands" validate:"required"`
+	AuthRequired []string               `yaml:"authRequired"`
+	Parameters   tools.Parameters       `yaml:"parameters"`
 }
 
 // validate interface
@@ -67,6 +71,10 @@ func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+func (cfg Config) ToolPolicy() *tools.Policy {
+	return cfg.Policy
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -81,9 +89,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	}
 
 	mcpManifest := tools.McpManifest{
-		Name:        cfg.Name,
-		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		Name:         cfg.Name,
+		Title:        cfg.Title,
+		Description:  cfg.Description,
+		InputSchema:  cfg.Parameters.McpManifest(),
+		OutputSchema: tools.McpOutputSchema(cfg.ResultSchema),
+		Annotations:  cfg.Annotations,
 	}
 
 	// finish tool setup
@@ -194,6 +205,10 @@ func replaceCommandsParams(commands [][]string, params tools.Parameters, paramVa
 				newCmd[j] = part
 				continue
 			}
+			if v == nil {
+				// commands can't pass NULL for optional parameters
+				return nil, fmt.Errorf("parameter %q has no value", part[1:])
+			}
 			if typeMap[part] == "array" {
 				for _, item := range v.([]any) {
 					// Nested arrays will only be expanded once
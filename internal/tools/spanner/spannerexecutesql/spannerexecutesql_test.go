@@ -20,7 +20,10 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	spannerdb "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannerexecutesql"
 )
 
@@ -75,6 +78,29 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with annotations",
+			in: `
+			tools:
+				example_tool:
+					kind: spanner-execute-sql
+					source: my-spanner-instance
+					description: some description
+					annotations:
+						idempotentHint: true
+						openWorldHint: false
+			`,
+			want: server.ToolConfigs{
+				"example_tool": spannerexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "spanner-execute-sql",
+					Source:       "my-spanner-instance",
+					Description:  "some description",
+					Annotations:  &tools.ToolAnnotations{IdempotentHint: boolPtr(true), OpenWorldHint: boolPtr(false)},
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -93,3 +119,48 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 	}
 
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestDefaultAnnotations(t *testing.T) {
+	srcs := map[string]sources.Source{"my-spanner-instance": &spannerdb.Source{}}
+	tcs := []struct {
+		desc string
+		cfg  spannerexecutesql.Config
+		want *tools.ToolAnnotations
+	}{
+		{
+			desc: "destructive by default",
+			cfg:  spannerexecutesql.Config{Name: "example_tool", Kind: "spanner-execute-sql", Source: "my-spanner-instance"},
+			want: tools.NewDestructiveAnnotations(),
+		},
+		{
+			desc: "read only",
+			cfg:  spannerexecutesql.Config{Name: "example_tool", Kind: "spanner-execute-sql", Source: "my-spanner-instance", ReadOnly: true},
+			want: tools.NewReadOnlyAnnotations(),
+		},
+		{
+			desc: "configured hints take precedence",
+			cfg: spannerexecutesql.Config{
+				Name:        "example_tool",
+				Kind:        "spanner-execute-sql",
+				Source:      "my-spanner-instance",
+				Annotations: &tools.ToolAnnotations{DestructiveHint: boolPtr(false)},
+			},
+			want: &tools.ToolAnnotations{ReadOnlyHint: boolPtr(false), DestructiveHint: boolPtr(false)},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool, err := tc.cfg.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("unable to initialize tool: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tool.McpManifest().Annotations); diff != "" {
+				t.Fatalf("incorrect annotations: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// Dialect is the SQL dialect of a statement, which determines how identifier
+// template parameters are quoted.
+type Dialect string
+
+const (
+	// DialectPostgres quotes identifiers with double quotes, e.g. "my table".
+	DialectPostgres Dialect = "postgresql"
+	// DialectSQLite quotes identifiers like DialectPostgres.
+	DialectSQLite Dialect = "sqlite"
+	// DialectMySQL quotes identifiers with backticks, e.g. `my table`.
+	DialectMySQL Dialect = "mysql"
+	// DialectSQLPlusPlus is the dialect of Couchbase, which quotes
+	// identifiers like DialectMySQL.
+	DialectSQLPlusPlus Dialect = "sqlpp"
+	// DialectMSSQL quotes identifiers with brackets, e.g. [my table].
+	DialectMSSQL Dialect = "mssql"
+	// DialectGoogleSQL is the dialect of BigQuery, Bigtable and Spanner. It
+	// quotes identifiers with backticks, e.g. `my table`.
+	DialectGoogleSQL Dialect = "googlesql"
+)
+
+// QuoteIdentifier quotes name as an identifier of dialect, so that it can be
+// inserted into a statement.
+func QuoteIdentifier(dialect Dialect, name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("identifier can't be empty")
+	}
+	if strings.ContainsRune(name, 0) {
+		return "", fmt.Errorf("identifier %q can't contain a NUL character", name)
+	}
+	switch dialect {
+	case DialectPostgres, DialectSQLite:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`, nil
+	case DialectMySQL, DialectSQLPlusPlus:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`", nil
+	case DialectMSSQL:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]", nil
+	case DialectGoogleSQL:
+		// quoted identifiers support the same escape sequences as strings
+		r := strings.NewReplacer(`\`, `\\`, "`", "\\`")
+		return "`" + r.Replace(name) + "`", nil
+	default:
+		return "", fmt.Errorf("unable to quote identifiers of dialect %q", dialect)
+	}
+}
+
+// TemplateParameter is a parameter whose value is inserted into the statement
+// of a tool as is. Since this bypasses the bound parameters of the database,
+// string values must either be one of AllowedValues, fully match
+// AllowedPattern, or be an Identifier that is quoted for the dialect of the
+// statement. For arrays, this applies to each item.
+type TemplateParameter struct {
+	Parameter
+	AllowedValues  []string
+	AllowedPattern string
+	Identifier     bool
+}
+
+var _ Parameter = &TemplateParameter{}
+
+// templateValidation are the fields of a TemplateParameter that validate its
+// values.
+type templateValidation struct {
+	AllowedValues  []string `yaml:"allowedValues"`
+	AllowedPattern string   `yaml:"allowedPattern"`
+	Identifier     bool     `yaml:"identifier"`
+}
+
+var templateValidationFields = []string{"allowedValues", "allowedPattern", "identifier"}
+
+// isStringTemplateParam returns true if the values of p contain strings,
+// which need to be validated.
+func isStringTemplateParam(p Parameter) bool {
+	if a, ok := p.(*ArrayParameter); ok {
+		return a.Items.GetType() == typeString
+	}
+	return p.GetType() == typeString
+}
+
+// validate checks that the type of the parameter can be inserted into a
+// statement, and that string values are validated.
+func (p *TemplateParameter) validate() error {
+	switch p.GetType() {
+	case typeString, typeInt, typeFloat, typeBool:
+	case typeArray:
+		switch p.Parameter.(*ArrayParameter).Items.GetType() {
+		case typeString, typeInt, typeFloat, typeBool:
+		default:
+			return fmt.Errorf("the items of template parameter %q must be strings, numbers or booleans", p.GetName())
+		}
+	default:
+		return fmt.Errorf("%q is not a valid type for template parameter %q", p.GetType(), p.GetName())
+	}
+	// a missing value can't be inserted into the statement
+	if !p.GetRequired() && p.GetDefault() == nil {
+		return fmt.Errorf("template parameter %q must be required or have a default", p.GetName())
+	}
+	validated := len(p.AllowedValues) > 0 || p.AllowedPattern != "" || p.Identifier
+	if !isStringTemplateParam(p.Parameter) {
+		if validated {
+			return fmt.Errorf("template parameter %q: allowedValues, allowedPattern and identifier only apply to strings", p.GetName())
+		}
+		return nil
+	}
+	if !validated {
+		return fmt.Errorf("template parameter %q must set one of allowedValues, allowedPattern or identifier", p.GetName())
+	}
+	if p.AllowedPattern != "" {
+		if _, err := compilePattern(anchorPattern(p.AllowedPattern)); err != nil {
+			return fmt.Errorf("template parameter %q: %w", p.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// anchorPattern anchors an allowedPattern, which has to match the whole value.
+func anchorPattern(pattern string) string {
+	return `^(?:` + pattern + `)$`
+}
+
+// checkValue returns an error if a string value isn't allowed.
+func (p *TemplateParameter) checkValue(v string) error {
+	if len(p.AllowedValues) > 0 && !slices.Contains(p.AllowedValues, v) {
+		return newConstraintError("allowedValues", "%q is not one of %q", v, p.AllowedValues)
+	}
+	if p.AllowedPattern != "" {
+		re, err := compilePattern(anchorPattern(p.AllowedPattern))
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(v) {
+			return newConstraintError("allowedPattern", "%q doesn't match %q", v, p.AllowedPattern)
+		}
+	}
+	if p.Identifier && v == "" {
+		return newConstraintError("identifier", "identifier can't be empty")
+	}
+	return nil
+}
+
+// check returns an error if the string values of v aren't allowed.
+func (p *TemplateParameter) check(v any) error {
+	switch v := v.(type) {
+	case string:
+		return p.checkValue(v)
+	case []any:
+		for idx, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				continue
+			}
+			if err := p.checkValue(s); err != nil {
+				return fmt.Errorf("invalid element #%d: %w", idx, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *TemplateParameter) Parse(v any) (any, error) {
+	v, err := p.Parameter.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// McpManifest returns the MCP manifest of the parameter, with allowedValues
+// and allowedPattern as "enum" and "pattern" constraints.
+func (p *TemplateParameter) McpManifest() ParameterMcpManifest {
+	m := p.Parameter.McpManifest()
+	target := &m
+	if m.Items != nil {
+		items := *m.Items
+		m.Items = &items
+		target = &items
+	}
+	if len(p.AllowedValues) > 0 {
+		target.Enum = toAny(p.AllowedValues)
+	}
+	if p.AllowedPattern != "" {
+		target.Pattern = anchorPattern(p.AllowedPattern)
+	}
+	return m
+}
+
+// MarshalYAML marshals the parameter with its validation fields.
+func (p *TemplateParameter) MarshalYAML() (any, error) {
+	b, err := yaml.Marshal(p.Parameter)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if len(p.AllowedValues) > 0 {
+		m["allowedValues"] = p.AllowedValues
+	}
+	if p.AllowedPattern != "" {
+		m["allowedPattern"] = p.AllowedPattern
+	}
+	if p.Identifier {
+		m["identifier"] = true
+	}
+	return m, nil
+}
+
+// TemplateParameters is a type used to unmarshal the templateParameters of a
+// tool. Each of them is a *TemplateParameter.
+type TemplateParameters []Parameter
+
+func (c *TemplateParameters) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(TemplateParameters, 0)
+	var rawList []map[string]any
+	if err := unmarshal(&rawList); err != nil {
+		return err
+	}
+	for _, raw := range rawList {
+		// the validation fields are decoded separately, since the strict
+		// decoder of the parameter doesn't know them
+		fields := make(map[string]any)
+		for _, k := range templateValidationFields {
+			if v, ok := raw[k]; ok {
+				fields[k] = v
+				delete(raw, k)
+			}
+		}
+		var v templateValidation
+		dec, err := util.NewStrictDecoder(fields)
+		if err != nil {
+			return fmt.Errorf("error creating decoder: %w", err)
+		}
+		if err := dec.DecodeContext(ctx, &v); err != nil {
+			return fmt.Errorf("unable to parse template parameter %q: %w", raw["name"], err)
+		}
+
+		var ps Parameters
+		dec, err = util.NewStrictDecoder([]map[string]any{raw})
+		if err != nil {
+			return fmt.Errorf("error creating decoder: %w", err)
+		}
+		if err := dec.DecodeContext(ctx, &ps); err != nil {
+			return err
+		}
+
+		p := &TemplateParameter{
+			Parameter:      ps[0],
+			AllowedValues:  v.AllowedValues,
+			AllowedPattern: v.AllowedPattern,
+			Identifier:     v.Identifier,
+		}
+		if err := p.validate(); err != nil {
+			return err
+		}
+		*c = append(*c, p)
+	}
+	return nil
+}
+
+// templateValue validates the value of a template parameter, and quotes it if
+// the parameter is an identifier.
+func templateValue(p Parameter, v any, dialect Dialect) (any, error) {
+	if v == nil {
+		return nil, fmt.Errorf("a value is required")
+	}
+	tp, ok := p.(*TemplateParameter)
+	if !ok {
+		// strings can only be inserted if they have been validated
+		if _, ok := v.(string); ok || isStringTemplateParam(p) {
+			return nil, fmt.Errorf("strings must be validated by allowedValues, allowedPattern or identifier")
+		}
+		return v, nil
+	}
+	if err := tp.check(v); err != nil {
+		return nil, err
+	}
+	if !tp.Identifier {
+		return v, nil
+	}
+	switch v := v.(type) {
+	case string:
+		return QuoteIdentifier(dialect, v)
+	case []any:
+		quoted := make([]any, len(v))
+		for idx, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("element #%d of an identifier is not a string", idx)
+			}
+			q, err := QuoteIdentifier(dialect, s)
+			if err != nil {
+				return nil, err
+			}
+			quoted[idx] = q
+		}
+		return quoted, nil
+	}
+	return v, nil
+}
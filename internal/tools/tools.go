@@ -61,6 +61,9 @@ func DecodeConfig(ctx context.Context, kind string, name string, decoder *yaml.D
 type ToolConfig interface {
 	ToolConfigKind() string
 	Initialize(map[string]sources.Source) (Tool, error)
+	// ToolPolicy returns the policy restricting the callers of the tool, or
+	// nil if every caller is allowed.
+	ToolPolicy() *Policy
 }
 
 type Tool interface {
@@ -82,10 +85,93 @@ type Manifest struct {
 type McpManifest struct {
 	// The name of the tool.
 	Name string `json:"name"`
+	// A human-readable title for the tool.
+	Title string `json:"title,omitempty"`
 	// A human-readable description of the tool.
 	Description string `json:"description,omitempty"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	InputSchema McpToolsSchema `json:"inputSchema,omitempty"`
+	// An optional JSON Schema object defining the structure of the tool's
+	// structured output.
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	// Optional hints describing the behavior of the tool.
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are hints that describe the behavior of a tool to MCP
+// clients, e.g. to decide which calls need a confirmation from the user.
+// Hints that are not set take the defaults of the MCP specification.
+type ToolAnnotations struct {
+	// If true, the tool does not modify its environment.
+	ReadOnlyHint *bool `yaml:"readOnlyHint" json:"readOnlyHint,omitempty"`
+	// If true, the tool may perform destructive updates to its environment.
+	// Only meaningful when the tool is not read-only.
+	DestructiveHint *bool `yaml:"destructiveHint" json:"destructiveHint,omitempty"`
+	// If true, calling the tool repeatedly with the same arguments has no
+	// additional effect. Only meaningful when the tool is not read-only.
+	IdempotentHint *bool `yaml:"idempotentHint" json:"idempotentHint,omitempty"`
+	// If true, the tool may interact with an open world of external entities.
+	OpenWorldHint *bool `yaml:"openWorldHint" json:"openWorldHint,omitempty"`
+}
+
+// NewReadOnlyAnnotations returns the annotations of a tool that does not
+// modify its environment.
+func NewReadOnlyAnnotations() *ToolAnnotations {
+	readOnly := true
+	return &ToolAnnotations{ReadOnlyHint: &readOnly}
+}
+
+// NewDestructiveAnnotations returns the annotations of a tool that may perform
+// destructive updates to its environment.
+func NewDestructiveAnnotations() *ToolAnnotations {
+	readOnly, destructive := false, true
+	return &ToolAnnotations{ReadOnlyHint: &readOnly, DestructiveHint: &destructive}
+}
+
+// GetAnnotationsOrDefault returns the annotations configured for a tool. Hints
+// that are not configured are taken from defaults, the annotations of its kind.
+func GetAnnotationsOrDefault(annotations, defaults *ToolAnnotations) *ToolAnnotations {
+	if annotations == nil {
+		return defaults
+	}
+	if defaults == nil {
+		return annotations
+	}
+	merged := *annotations
+	if merged.ReadOnlyHint == nil {
+		merged.ReadOnlyHint = defaults.ReadOnlyHint
+	}
+	if merged.DestructiveHint == nil {
+		merged.DestructiveHint = defaults.DestructiveHint
+	}
+	if merged.IdempotentHint == nil {
+		merged.IdempotentHint = defaults.IdempotentHint
+	}
+	if merged.OpenWorldHint == nil {
+		merged.OpenWorldHint = defaults.OpenWorldHint
+	}
+	return &merged
+}
+
+// McpStructuredResultKey is the property of the MCP structured output that
+// holds the results of a tool invocation. Structured output must be an
+// object, while tools return a list of results.
+const McpStructuredResultKey = "result"
+
+// McpOutputSchema returns the MCP output schema of a tool whose results are
+// described by the JSON Schema resultSchema. It returns nil if resultSchema is
+// not set.
+func McpOutputSchema(resultSchema map[string]any) map[string]any {
+	if len(resultSchema) == 0 {
+		return nil
+	}
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			McpStructuredResultKey: resultSchema,
+		},
+		"required": []string{McpStructuredResultKey},
+	}
 }
 
 // Helper function that returns if a tool invocation request is authorized
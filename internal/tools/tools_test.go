@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestGetAnnotationsOrDefault(t *testing.T) {
+	tcs := []struct {
+		name        string
+		annotations *tools.ToolAnnotations
+		defaults    *tools.ToolAnnotations
+		want        *tools.ToolAnnotations
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name:     "defaults only",
+			defaults: tools.NewDestructiveAnnotations(),
+			want:     &tools.ToolAnnotations{ReadOnlyHint: boolPtr(false), DestructiveHint: boolPtr(true)},
+		},
+		{
+			name:        "annotations only",
+			annotations: &tools.ToolAnnotations{OpenWorldHint: boolPtr(false)},
+			want:        &tools.ToolAnnotations{OpenWorldHint: boolPtr(false)},
+		},
+		{
+			name:        "annotations override defaults",
+			annotations: &tools.ToolAnnotations{DestructiveHint: boolPtr(false), IdempotentHint: boolPtr(true)},
+			defaults:    tools.NewDestructiveAnnotations(),
+			want: &tools.ToolAnnotations{
+				ReadOnlyHint:    boolPtr(false),
+				DestructiveHint: boolPtr(false),
+				IdempotentHint:  boolPtr(true),
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tools.GetAnnotationsOrDefault(tc.annotations, tc.defaults)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected annotations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMcpOutputSchema(t *testing.T) {
+	if got := tools.McpOutputSchema(nil); got != nil {
+		t.Fatalf("unexpected output schema: %v", got)
+	}
+	resultSchema := map[string]any{"type": "array"}
+	want := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"result": resultSchema},
+		"required":   []string{"result"},
+	}
+	if diff := cmp.Diff(want, tools.McpOutputSchema(resultSchema)); diff != "" {
+		t.Fatalf("unexpected output schema (-want +got):\n%s", diff)
+	}
+}
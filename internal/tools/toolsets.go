@@ -21,6 +21,8 @@ import (
 type ToolsetConfig struct {
 	Name      string   `yaml:"name"`
 	ToolNames []string `yaml:",inline"`
+	// Policy restricts the callers of the tools of the toolset.
+	Policy *Policy `yaml:"policy"`
 }
 
 type Toolset struct {
@@ -41,7 +43,7 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	var toolset Toolset
 	toolset.Name = t.Name
 	if !IsValidName(toolset.Name) {
-		return toolset, fmt.Errorf("invalid toolset name: %s", t)
+		return toolset, fmt.Errorf("invalid toolset name: %s", t.Name)
 	}
 	toolset.Tools = make([]*Tool, len(t.ToolNames))
 	toolset.Manifest = ToolsetManifest{
@@ -51,7 +53,7 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	for _, toolName := range t.ToolNames {
 		tool, ok := toolsMap[toolName]
 		if !ok {
-			return toolset, fmt.Errorf("tool does not exist: %s", t)
+			return toolset, fmt.Errorf("tool does not exist: %s", toolName)
 		}
 		toolset.Tools = append(toolset.Tools, &tool)
 		toolset.Manifest.ToolsManifest[toolName] = tool.Manifest()
@@ -105,3 +105,61 @@ func LoggerFromContext(ctx context.Context) (log.Logger, error) {
 	}
 	return nil, fmt.Errorf("unable to retrieve logger")
 }
+
+// ProgressReporter reports the progress of a long running tool invocation.
+// Total is 0 if it is unknown.
+type ProgressReporter func(progress, total float64, message string)
+
+// progressReporterKey is the key used to store the progress reporter within context
+const progressReporterKey contextKey = "progressReporter"
+
+// WithProgressReporter adds a progress reporter into the context as a value
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey, reporter)
+}
+
+// ReportProgress reports the progress of a tool invocation to its caller.
+// It does nothing if the caller did not ask for progress.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	if reporter, ok := ctx.Value(progressReporterKey).(ProgressReporter); ok {
+		reporter(progress, total, message)
+	}
+}
+
+// authClaimsKey is the key used to store the verified auth claims within
+// context
+const authClaimsKey contextKey = "authClaims"
+
+// WithAuthClaims adds the claims verified by the auth services for a request
+// into the context as a value. claims maps the name of each verified auth
+// service to the claims retrieved from it.
+func WithAuthClaims(ctx context.Context, claims map[string]map[string]any) context.Context {
+	return context.WithValue(ctx, authClaimsKey, claims)
+}
+
+// AuthClaimsFromContext retrieves the verified auth claims. It returns an empty
+// map if no auth service was verified for the request.
+func AuthClaimsFromContext(ctx context.Context) map[string]map[string]any {
+	if claims, ok := ctx.Value(authClaimsKey).(map[string]map[string]any); ok && claims != nil {
+		return claims
+	}
+	return make(map[string]map[string]any)
+}
+
+// authTokensKey is the key used to store the verified auth tokens within
+// context
+const authTokensKey contextKey = "authTokens"
+
+// WithAuthTokens adds the raw tokens verified by the auth services for a
+// request into the context as a value. tokens maps the name of each verified
+// auth service to its token.
+func WithAuthTokens(ctx context.Context, tokens map[string]string) context.Context {
+	return context.WithValue(ctx, authTokensKey, tokens)
+}
+
+// AuthTokenFromContext retrieves the verified token of an auth service.
+func AuthTokenFromContext(ctx context.Context, authService string) (string, bool) {
+	tokens, _ := ctx.Value(authTokensKey).(map[string]string)
+	token, ok := tokens[authService]
+	return token, ok && token != ""
+}
@@ -280,7 +280,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT TO_INT64(cf['age']) as age, TO_INT64(cf['id']) as id, CAST(cf['name'] AS string) as name, FROM {{.tableName}};",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			tests.NewTableNameTemplateParam(),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -290,7 +290,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT TO_INT64(cf['age']) as age, TO_INT64(cf['id']) as id, CAST(cf['name'] AS string) as name, FROM {{.tableName}} WHERE TO_INT64(cf['id']) = @id;",
 		"parameters":  []tools.Parameter{tools.NewIntParameter("id", "the id of the user")},
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			tests.NewTableNameTemplateParam(),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -299,8 +299,11 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT {{array .fields}}, FROM {{.tableName}};",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewArrayParameter("fields", "The fields to select from", tools.NewStringParameter("field", "A field that will be returned from the query.")),
+			tests.NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:     tools.NewArrayParameter("fields", "The fields to select from", tools.NewStringParameter("field", "A field that will be returned from the query.")),
+				AllowedValues: []string{"CAST(cf['name'] AS string) as name"},
+			},
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -310,8 +313,11 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT TO_INT64(cf['age']) as age, TO_INT64(cf['id']) as id, CAST(cf['name'] AS string) as name, FROM {{.tableName}} WHERE {{.columnFilter}} = @name;",
 		"parameters":  []tools.Parameter{tools.NewStringParameter("name", "the name of the user")},
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewStringParameter("columnFilter", "some description"),
+			tests.NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:     tools.NewStringParameter("columnFilter", "some description"),
+				AllowedValues: []string{"CAST(cf['name'] AS string)"},
+			},
 		},
 	}
 	config["tools"] = toolsMap
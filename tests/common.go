@@ -129,6 +129,24 @@ func AddPgExecuteSqlConfig(t *testing.T, config map[string]any) map[string]any {
 	return config
 }
 
+// NewTableNameTemplateParam returns the template parameter for the name of the
+// test table. It isn't quoted, since some tests pass fully qualified names.
+func NewTableNameTemplateParam() *tools.TemplateParameter {
+	return &tools.TemplateParameter{
+		Parameter:      tools.NewStringParameter("tableName", "some description"),
+		AllowedPattern: "[\\w.`]+",
+	}
+}
+
+// NewValuesTemplateParam returns the template parameter for the values
+// inserted into the test table.
+func NewValuesTemplateParam() *tools.TemplateParameter {
+	return &tools.TemplateParameter{
+		Parameter:      tools.NewStringParameter("values", "The values to insert as a comma separated string"),
+		AllowedPattern: `[\w ',]+`,
+	}
+}
+
 func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplSelectCombined, tmplSelectFilterCombined string, tmplSelectAll string) map[string]any {
 	toolsMap, ok := config["tools"].(map[string]any)
 	if !ok {
@@ -146,8 +164,11 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Create table tool with template parameters",
 		"statement":   "CREATE TABLE {{.tableName}} ({{array .columns}})",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewArrayParameter("columns", "The columns to create", tools.NewStringParameter("column", "A column name that will be created")),
+			NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:      tools.NewArrayParameter("columns", "The columns to create", tools.NewStringParameter("column", "A column name that will be created")),
+				AllowedPattern: `\w+ [\w()]+`,
+			},
 		},
 	}
 	toolsMap["insert-table-templateParams-tool"] = map[string]any{
@@ -156,9 +177,12 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Insert tool with template parameters",
 		"statement":   "INSERT INTO {{.tableName}} ({{array .columns}}) VALUES ({{.values}})",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewArrayParameter("columns", "The columns to insert into", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
-			tools.NewStringParameter("values", "The values to insert as a comma separated string"),
+			NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:  tools.NewArrayParameter("columns", "The columns to insert into", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+				Identifier: true,
+			},
+			NewValuesTemplateParam(),
 		},
 	}
 	toolsMap["select-templateParams-tool"] = map[string]any{
@@ -167,7 +191,7 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Create table tool with template parameters",
 		"statement":   selectAll,
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			NewTableNameTemplateParam(),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -177,7 +201,7 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"statement":   tmplSelectCombined,
 		"parameters":  []tools.Parameter{tools.NewIntParameter("id", "the id of the user")},
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			NewTableNameTemplateParam(),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -186,8 +210,11 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT {{array .fields}} FROM {{.tableName}} ORDER BY id",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewArrayParameter("fields", "The fields to select from", tools.NewStringParameter("field", "A field that will be returned from the query.")),
+			NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:  tools.NewArrayParameter("fields", "The fields to select from", tools.NewStringParameter("field", "A field that will be returned from the query.")),
+				Identifier: true,
+			},
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -197,8 +224,11 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"statement":   tmplSelectFilterCombined,
 		"parameters":  []tools.Parameter{tools.NewStringParameter("name", "the name of the user")},
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewStringParameter("columnFilter", "some description"),
+			NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:     tools.NewStringParameter("columnFilter", "some description"),
+				AllowedValues: []string{"id", "name", "age"},
+			},
 		},
 	}
 	toolsMap["drop-table-templateParams-tool"] = map[string]any{
@@ -207,7 +237,7 @@ func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplS
 		"description": "Drop table tool with template parameters",
 		"statement":   "DROP TABLE IF EXISTS {{.tableName}}",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			NewTableNameTemplateParam(),
 		},
 	}
 	config["tools"] = toolsMap
@@ -303,9 +303,12 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Insert tool with template parameters",
 		"statement":   "INSERT INTO {{.tableName}} ({{array .columns}}) VALUES ({{.values}})",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewArrayParameter("columns", "The columns to insert into", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
-			tools.NewStringParameter("values", "The values to insert as a comma separated string"),
+			tests.NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:  tools.NewArrayParameter("columns", "The columns to insert into", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+				Identifier: true,
+			},
+			tests.NewValuesTemplateParam(),
 		},
 	}
 	toolsMap["select-templateParams-tool"] = map[string]any{
@@ -314,7 +317,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT * FROM {{.tableName}}",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			tests.NewTableNameTemplateParam(),
 		},
 	}
 	toolsMap["select-templateParams-combined-tool"] = map[string]any{
@@ -324,7 +327,7 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT * FROM {{.tableName}} WHERE id = @id",
 		"parameters":  []tools.Parameter{tools.NewIntParameter("id", "the id of the user")},
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
+			tests.NewTableNameTemplateParam(),
 		},
 	}
 	toolsMap["select-fields-templateParams-tool"] = map[string]any{
@@ -333,8 +336,11 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"description": "Create table tool with template parameters",
 		"statement":   "SELECT {{array .fields}} FROM {{.tableName}}",
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewArrayParameter("fields", "The fields to select from", tools.NewStringParameter("field", "A field that will be returned from the query.")),
+			tests.NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:  tools.NewArrayParameter("fields", "The fields to select from", tools.NewStringParameter("field", "A field that will be returned from the query.")),
+				Identifier: true,
+			},
 		},
 	}
 	toolsMap["select-filter-templateParams-combined-tool"] = map[string]any{
@@ -344,8 +350,11 @@ func addTemplateParamConfig(t *testing.T, config map[string]any) map[string]any
 		"statement":   "SELECT * FROM {{.tableName}} WHERE {{.columnFilter}} = @name",
 		"parameters":  []tools.Parameter{tools.NewStringParameter("name", "the name of the user")},
 		"templateParameters": []tools.Parameter{
-			tools.NewStringParameter("tableName", "some description"),
-			tools.NewStringParameter("columnFilter", "some description"),
+			tests.NewTableNameTemplateParam(),
+			&tools.TemplateParameter{
+				Parameter:     tools.NewStringParameter("columnFilter", "some description"),
+				AllowedValues: []string{"id", "name", "age"},
+			},
 		},
 	}
 	config["tools"] = toolsMap
@@ -367,6 +367,13 @@ func RunToolInvokeWithTemplateParameters(t *testing.T, tableName string, config
 			want:          config.select1Want,
 			isErr:         false,
 		},
+		{
+			name:          "invoke select-filter-templateParams-combined-tool with a column filter that isn't allowed",
+			api:           "http://127.0.0.1:5000/api/tool/select-filter-templateParams-combined-tool/invoke",
+			requestHeader: map[string]string{},
+			requestBody:   bytes.NewBuffer([]byte(fmt.Sprintf(`{"name": "Alex", "tableName": "%s", "columnFilter": "1 = 1 OR name"}`, tableName))),
+			isErr:         true,
+		},
 		{
 			name:          "invoke drop-table-templateParams-tool",
 			ddl:           true,